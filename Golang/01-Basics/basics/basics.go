@@ -0,0 +1,61 @@
+// Package basics holds the functions and constants from the "Basics" section of the Go tour
+// so they can be imported and reused outside of the lesson's demo program.
+package basics
+
+// functions can take >= 0 arguments.
+// the function `Add` below takes two parameters of type "int".
+// notice the type comes after the variable name.
+
+// Add returns the sum of x and y.
+func Add(x int, y int) int {
+	return x + y
+}
+
+// when two or more consecutive parameters share the same type, you can omit the
+// type from all but the last parameter. See the function `Subtract` below.
+
+// Subtract returns the difference x - y.
+func Subtract(x, y int) int {
+	return x - y
+}
+
+// a function can return any number of results. The function `Swap` below returns two strings.
+
+// Swap returns its two arguments in reverse order.
+func Swap(x, y string) (string, string) {
+	return y, x
+}
+
+// return values can be named. If so, they are treated as variables defined at the top of the function.
+// these names should be used to document the meaning of the return values.
+// a return statement without arguments returns the named return values and is called a
+// "naked" return.
+// naked return statements should only be used in short functions, as in the example below;
+// they can harm readability in longer functions.
+
+// Split divides sum into two parts, x (four ninths of sum) and y (the remainder).
+func Split(sum int) (x, y int) {
+	x = sum * 4 / 9
+	y = sum - x
+
+	return
+}
+
+/*
+ * Numeric constants are high-precision values.
+ * An untyped constant takes the type needed by its context.
+ */
+const (
+	Big   = 1 << 100  // shift left 1 by 100 places (binary number with 1 followed by 100 zeros)
+	Small = Big >> 99 // shift it right again 99 places, resulting in 2 (10, or 1 << 1).
+)
+
+// NeedInt returns x*10 + 1.
+func NeedInt(x int) int {
+	return x*10 + 1
+}
+
+// NeedFloat returns x * 0.1.
+func NeedFloat(x float64) float64 {
+	return x * 0.1
+}
@@ -0,0 +1,443 @@
+package basics
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+/*
+ * Big and Small only exist while the program compiles: the compiler works out 1 << 100 exactly,
+ * but the value has to fit an int or a float64 by the time it is used, so NeedInt(Big) doesn't compile.
+ * The math/big package does the same arithmetic at run time, so the same expressions can come from
+ * user input. Converting the exact result back to a fixed-size type reports an overflow instead of
+ * refusing to compile.
+ */
+
+// Limits on what Eval accepts. MaxBits is the one that bounds time and memory: every intermediate value,
+// numerator and denominator, must fit in that many bits, however it was reached. MaxShift and MaxExponent
+// only reject single steps that are far too large up front (1 << 1e9, 1e-1000000) with a clearer message;
+// chained shifts and products are caught by MaxBits.
+const (
+	MaxBits     = 100000 // about 30000 decimal digits
+	MaxShift    = 10000  // largest shift count
+	MaxExponent = 10000  // largest exponent, positive or negative, in a floating-point literal
+)
+
+// Errors wrapped by ConversionError.
+var (
+	ErrOverflow  = errors.New("overflows")
+	ErrTruncated = errors.New("truncated to integer")
+)
+
+// ConversionError reports an exact value that can't be converted to a fixed-size type.
+type ConversionError struct {
+	Value string // the exact value, as FormatExact writes it, or rounded like 1.14813e+602 if that is long
+	Type  string // the type converted to, e.g. "int"
+	Err   error  // ErrOverflow or ErrTruncated
+}
+
+func (e *ConversionError) Error() string {
+	if e.Err == ErrTruncated {
+		return fmt.Sprintf("basics: constant %s truncated to integer", e.Value)
+	}
+	return fmt.Sprintf("basics: constant %s %v %s", e.Value, e.Err, e.Type)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// maxValueLen is the longest exact value a ConversionError spells out in full.
+const maxValueLen = 40
+
+// errorValue returns x for a ConversionError: exact if that is short, otherwise rounded to six digits
+// the way the compiler reports long constants.
+func errorValue(x *big.Rat) string {
+	if s := FormatExact(x); len(s) <= maxValueLen {
+		return s
+	}
+
+	return new(big.Float).SetRat(x).Text('g', 6)
+}
+
+// ExprError reports why Eval rejected an expression.
+type ExprError struct {
+	Expr   string // the expression being evaluated
+	Offset int    // byte offset in Expr where the problem was found
+	Msg    string
+}
+
+// maxExprLen is how much of a long expression ExprError's message quotes.
+const maxExprLen = 60
+
+func (e *ExprError) Error() string {
+	expr := e.Expr
+	if r := []rune(expr); len(r) > maxExprLen {
+		expr = string(r[:maxExprLen-3]) + "..."
+	}
+
+	return fmt.Sprintf("basics: evaluating %q: at offset %d: %s", expr, e.Offset, e.Msg)
+}
+
+// constant is an untyped constant during evaluation. Like the compiler, Eval keeps integer and floating
+// point constants apart: 7 / 2 is 3, but 7.0 / 2 is 3.5.
+type constant struct {
+	val   *big.Rat
+	float bool
+}
+
+// exactBig is Big, computed at run time.
+var exactBig = new(big.Int).Lsh(big.NewInt(1), 100)
+
+// names are the identifiers Eval knows and their values.
+var names = map[string]*big.Int{
+	"big":   exactBig,
+	"Big":   exactBig,
+	"small": new(big.Int).Rsh(exactBig, 99),
+	"Small": new(big.Int).Rsh(exactBig, 99),
+}
+
+// Eval evaluates expr exactly, the way the compiler evaluates an untyped constant expression. expr is Go
+// syntax made of integer and decimal literals, the names big and small (or Big and Small), parentheses,
+// unary + - ^ and the binary operators + - * / % << >> & | ^ &^. For example, Eval("big >> 99") is 2.
+func Eval(expr string) (*big.Rat, error) {
+	fset := token.NewFileSet()
+	e, err := parser.ParseExprFrom(fset, "", expr, 0)
+	if err != nil {
+		var list scanner.ErrorList
+		if errors.As(err, &list) && len(list) > 0 {
+			return nil, &ExprError{Expr: expr, Offset: list[0].Pos.Offset, Msg: list[0].Msg}
+		}
+		return nil, &ExprError{Expr: expr, Msg: err.Error()}
+	}
+
+	ev := evaluator{expr: expr, file: fset.File(e.Pos())}
+	c, err := ev.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.val, nil
+}
+
+// evaluator walks the syntax tree of one expression.
+type evaluator struct {
+	expr string
+	file *token.File
+}
+
+// fail returns an ExprError for the node at pos.
+func (ev *evaluator) fail(pos token.Pos, format string, args ...any) error {
+	return &ExprError{Expr: ev.expr, Offset: ev.file.Offset(pos), Msg: fmt.Sprintf(format, args...)}
+}
+
+// eval evaluates e and checks that its value is within MaxBits.
+func (ev *evaluator) eval(e ast.Expr) (constant, error) {
+	c, err := ev.node(e)
+	if err != nil {
+		return constant{}, err
+	}
+	if c.val.Num().BitLen() > MaxBits || c.val.Denom().BitLen() > MaxBits {
+		return constant{}, ev.fail(e.Pos(), "value too large (more than %d bits)", MaxBits)
+	}
+
+	return c, nil
+}
+
+func (ev *evaluator) node(e ast.Expr) (constant, error) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		return ev.literal(e)
+	case *ast.Ident:
+		if n, ok := names[e.Name]; ok {
+			return constant{val: new(big.Rat).SetInt(n)}, nil
+		}
+		return constant{}, ev.fail(e.Pos(), "undefined: %s (want big or small)", e.Name)
+	case *ast.ParenExpr:
+		return ev.eval(e.X)
+	case *ast.UnaryExpr:
+		return ev.unary(e)
+	case *ast.BinaryExpr:
+		return ev.binary(e)
+	default:
+		return constant{}, ev.fail(e.Pos(), "not a constant expression")
+	}
+}
+
+func (ev *evaluator) literal(e *ast.BasicLit) (constant, error) {
+	switch e.Kind {
+	case token.INT:
+		n, ok := new(big.Int).SetString(e.Value, 0)
+		if !ok {
+			return constant{}, ev.fail(e.Pos(), "malformed integer %s", e.Value)
+		}
+		return constant{val: new(big.Rat).SetInt(n)}, nil
+	case token.FLOAT:
+		lit := strings.ReplaceAll(e.Value, "_", "")
+		if exp, ok := literalExponent(lit); !ok || exp > MaxExponent || exp < -MaxExponent {
+			return constant{}, ev.fail(e.Pos(), "exponent of %s too large (max %d)", e.Value, MaxExponent)
+		}
+		r, ok := new(big.Rat).SetString(lit)
+		if !ok {
+			return constant{}, ev.fail(e.Pos(), "unsupported floating-point literal %s", e.Value)
+		}
+		return constant{val: r, float: true}, nil
+	default:
+		return constant{}, ev.fail(e.Pos(), "%s literals are not supported", strings.ToLower(e.Kind.String()))
+	}
+}
+
+// literalExponent returns the exponent of a floating-point literal without underscores: what follows the
+// e of a decimal literal or the p of a hexadecimal one, or 0 if there is none. It returns false if the
+// exponent doesn't even fit an int.
+func literalExponent(lit string) (int, bool) {
+	marker := "eE"
+	if strings.HasPrefix(lit, "0x") || strings.HasPrefix(lit, "0X") {
+		marker = "pP"
+	}
+	i := strings.IndexAny(lit, marker)
+	if i < 0 {
+		return 0, true
+	}
+
+	exp, err := strconv.Atoi(lit[i+1:])
+	return exp, err == nil
+}
+
+func (ev *evaluator) unary(e *ast.UnaryExpr) (constant, error) {
+	x, err := ev.eval(e.X)
+	if err != nil {
+		return constant{}, err
+	}
+
+	switch e.Op {
+	case token.ADD:
+		return x, nil
+	case token.SUB:
+		return constant{val: new(big.Rat).Neg(x.val), float: x.float}, nil
+	case token.XOR:
+		if x.float {
+			return constant{}, ev.fail(e.OpPos, "operator ^ not defined on untyped float")
+		}
+		// ^x is -x - 1 for an untyped integer, which has no fixed width.
+		n := new(big.Int).Not(x.val.Num())
+		return constant{val: new(big.Rat).SetInt(n)}, nil
+	default:
+		return constant{}, ev.fail(e.OpPos, "operator %s not supported", e.Op)
+	}
+}
+
+func (ev *evaluator) binary(e *ast.BinaryExpr) (constant, error) {
+	x, err := ev.eval(e.X)
+	if err != nil {
+		return constant{}, err
+	}
+	y, err := ev.eval(e.Y)
+	if err != nil {
+		return constant{}, err
+	}
+
+	if e.Op == token.SHL || e.Op == token.SHR {
+		return ev.shift(e, x, y)
+	}
+
+	float := x.float || y.float
+	r := new(big.Rat)
+	switch e.Op {
+	case token.ADD:
+		return constant{val: r.Add(x.val, y.val), float: float}, nil
+	case token.SUB:
+		return constant{val: r.Sub(x.val, y.val), float: float}, nil
+	case token.MUL:
+		return constant{val: r.Mul(x.val, y.val), float: float}, nil
+	case token.QUO, token.REM:
+		if y.val.Sign() == 0 {
+			return constant{}, ev.fail(e.OpPos, "division by zero")
+		}
+		if float && e.Op == token.QUO {
+			return constant{val: r.Quo(x.val, y.val), float: true}, nil
+		}
+	case token.AND, token.OR, token.XOR, token.AND_NOT:
+	default:
+		return constant{}, ev.fail(e.OpPos, "operator %s not supported", e.Op)
+	}
+
+	// the rest only apply to integers.
+	if float {
+		return constant{}, ev.fail(e.OpPos, "operator %s not defined on untyped float", e.Op)
+	}
+
+	a, b, n := x.val.Num(), y.val.Num(), new(big.Int)
+	switch e.Op {
+	case token.QUO:
+		n.Quo(a, b) // truncates toward zero, as Go does
+	case token.REM:
+		n.Rem(a, b)
+	case token.AND:
+		n.And(a, b)
+	case token.OR:
+		n.Or(a, b)
+	case token.XOR:
+		n.Xor(a, b)
+	case token.AND_NOT:
+		n.AndNot(a, b)
+	}
+
+	return constant{val: r.SetInt(n)}, nil
+}
+
+// shift evaluates x << y or x >> y. As in Go, x must have an integer value, even if it is written as a
+// float like 2.0, and y must be a non-negative integer.
+func (ev *evaluator) shift(e *ast.BinaryExpr, x, y constant) (constant, error) {
+	if !x.val.IsInt() {
+		return constant{}, ev.fail(e.X.Pos(), "shifted operand %s must be integer", FormatExact(x.val))
+	}
+	if !y.val.IsInt() || y.val.Sign() < 0 {
+		return constant{}, ev.fail(e.Y.Pos(), "invalid shift count %s", FormatExact(y.val))
+	}
+	if y.val.Num().Cmp(big.NewInt(MaxShift)) > 0 {
+		return constant{}, ev.fail(e.Y.Pos(), "shift count %s too large (max %d)", FormatExact(y.val), MaxShift)
+	}
+
+	n, count := new(big.Int), uint(y.val.Num().Uint64())
+	if e.Op == token.SHL {
+		n.Lsh(x.val.Num(), count)
+	} else {
+		n.Rsh(x.val.Num(), count)
+	}
+
+	return constant{val: new(big.Rat).SetInt(n)}, nil
+}
+
+// NeedIntExact returns x*10 + 1 exactly, as NeedInt would if int had no limit.
+func NeedIntExact(x *big.Int) *big.Int {
+	n := new(big.Int).Mul(x, big.NewInt(10))
+	return n.Add(n, big.NewInt(1))
+}
+
+// NeedFloatExact returns x * 0.1 exactly, as NeedFloat would with no rounding.
+func NeedFloatExact(x *big.Rat) *big.Rat {
+	return new(big.Rat).Quo(x, big.NewRat(10, 1))
+}
+
+// Integer returns x as a big.Int, or a ConversionError wrapping ErrTruncated if x has a fractional part.
+func Integer(x *big.Rat) (*big.Int, error) {
+	if !x.IsInt() {
+		return nil, &ConversionError{Value: errorValue(x), Type: "int", Err: ErrTruncated}
+	}
+
+	return new(big.Int).Set(x.Num()), nil
+}
+
+// ToInt converts x to an int, or returns a ConversionError wrapping ErrOverflow if it doesn't fit.
+func ToInt(x *big.Int) (int, error) {
+	if !x.IsInt64() || x.Int64() < math.MinInt || x.Int64() > math.MaxInt {
+		return 0, &ConversionError{Value: errorValue(new(big.Rat).SetInt(x)), Type: "int", Err: ErrOverflow}
+	}
+
+	return int(x.Int64()), nil
+}
+
+// ToFloat64 converts x to the nearest float64, or returns a ConversionError wrapping ErrOverflow if x is
+// beyond the largest finite float64.
+func ToFloat64(x *big.Rat) (float64, error) {
+	f, _ := x.Float64()
+	if math.IsInf(f, 0) {
+		return 0, &ConversionError{Value: errorValue(x), Type: "float64", Err: ErrOverflow}
+	}
+
+	return f, nil
+}
+
+// FormatExact writes x without rounding: as an integer, as a decimal if it has a finite decimal
+// expansion (1267650600228229401496703205376, 0.2), and as a fraction otherwise (1/3).
+func FormatExact(x *big.Rat) string {
+	if x.IsInt() {
+		return x.Num().String()
+	}
+
+	// a fraction in lowest terms has a finite decimal expansion if its denominator is 2^a * 5^b, and then
+	// it needs max(a, b) digits after the point.
+	twos := x.Denom().TrailingZeroBits()
+	d := new(big.Int).Rsh(x.Denom(), twos)
+
+	// divide out 5^(2^k) for k from the largest power no bigger than d down to 0, which counts the fives in
+	// a few dozen divisions however many there are.
+	powers := []*big.Int{big.NewInt(5)}
+	for p := powers[0]; ; {
+		p = new(big.Int).Mul(p, p)
+		if p.Cmp(d) > 0 {
+			break
+		}
+		powers = append(powers, p)
+	}
+	fives := uint(0)
+	q, r := new(big.Int), new(big.Int)
+	for k := len(powers) - 1; k >= 0; k-- {
+		if q.QuoRem(d, powers[k], r); r.Sign() == 0 {
+			d, q = q, d
+			fives += 1 << k
+		}
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return x.RatString()
+	}
+
+	return x.FloatString(int(max(twos, fives)))
+}
+
+// Type is a fixed-size type an exact value can be converted to, or Exact to keep it as it is.
+type Type string
+
+const (
+	Exact   Type = "exact"
+	Int     Type = "int"
+	Float64 Type = "float64"
+)
+
+// String returns the name of the type.
+func (t *Type) String() string {
+	if t == nil || *t == "" {
+		return string(Exact)
+	}
+
+	return string(*t)
+}
+
+// Set parses s as a Type, so a Type can be bound to a flag.
+func (t *Type) Set(s string) error {
+	switch Type(s) {
+	case Exact, Int, Float64:
+		*t = Type(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown type %q (want %s, %s or %s)", s, Exact, Int, Float64)
+	}
+}
+
+// Convert converts x to t: an int, a float64, or for Exact (and the zero Type) the string FormatExact
+// writes. Conversions that lose the integer part or overflow return a ConversionError.
+func Convert(x *big.Rat, t Type) (any, error) {
+	switch t {
+	case Int:
+		n, err := Integer(x)
+		if err != nil {
+			return nil, err
+		}
+		return ToInt(n)
+	case Float64:
+		return ToFloat64(x)
+	case Exact, "":
+		return FormatExact(x), nil
+	default:
+		return nil, fmt.Errorf("basics: unknown type %q", string(t))
+	}
+}
@@ -0,0 +1,205 @@
+package basics
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"1 << 100", "1267650600228229401496703205376"},
+		{"big", "1267650600228229401496703205376"},
+		{"Big >> 99", "2"},
+		{"small", "2"},
+		{"big * 10 + 1", "12676506002282294014967032053761"},
+		{"big * 0.1", "126765060022822940149670320537.6"},
+		{"7 / 2", "3"},
+		{"-7 / 2", "-3"},
+		{"-7 % 2", "-1"},
+		{"7.0 / 2", "3.5"},
+		{"1 / 3.0", "1/3"},
+		{"2.0 << 3", "16"},
+		{"^0", "-1"},
+		{"0xff &^ 0x0f | 1 ^ 3", "242"},
+		{"1_000_000 * 1e3", "1000000000"},
+		{"(1 + 2) * 3", "9"},
+		{"3.0 / (5 * 5 * 5 * 5 * 3)", "0.0016"},
+		{"0x1p-3", "0.125"},
+		{"1e10000 / 1e10000", "1"},
+	}
+
+	for _, tt := range tests {
+		got, err := Eval(tt.expr)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", tt.expr, err)
+			continue
+		}
+		if s := FormatExact(got); s != tt.want {
+			t.Errorf("Eval(%q) = %s, want %s", tt.expr, s, tt.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+		msg    string
+	}{
+		{"1 +", 3, "expected operand"},
+		{"huge", 0, "undefined: huge"},
+		{"1 / 0", 2, "division by zero"},
+		{"2.5 << 1", 0, "shifted operand 2.5 must be integer"},
+		{"1 << -1", 5, "invalid shift count -1"},
+		{"1 << 100000", 5, "too large"},
+		{"1 + 1e-1000000", 4, "exponent of 1e-1000000 too large (max 10000)"},
+		{"1e10001", 0, "too large"},
+		{"0x1p99999999999999999999", 0, "too large"},
+		// each shift is allowed, but the value they build is not.
+		{"1" + strings.Repeat("<<10000", 12), 0, "value too large (more than 100000 bits)"},
+		{"big * big * big * big * (1<<10000) * (1<<10000) * (1<<10000) * (1<<10000) * (1<<10000) * (1<<10000) * (1<<10000) * (1<<10000) * (1<<10000) * (1<<10000)", 0, "value too large"},
+		{"1.0 / (1<<10000) / (1<<10000) / (1<<10000) / (1<<10000) / (1<<10000) / (1<<10000) / (1<<10000) / (1<<10000) / (1<<10000) / (1<<10000)", 0, "value too large"},
+		{"7.0 % 2", 4, "operator % not defined on untyped float"},
+		{`"big"`, 0, "string literals are not supported"},
+		{"len(big)", 0, "not a constant expression"},
+	}
+
+	for _, tt := range tests {
+		_, err := Eval(tt.expr)
+		var eerr *ExprError
+		if !errors.As(err, &eerr) {
+			t.Errorf("Eval(%q) error = %v, want an *ExprError", tt.expr, err)
+			continue
+		}
+		if eerr.Offset != tt.offset || !strings.Contains(eerr.Msg, tt.msg) {
+			t.Errorf("Eval(%q) error at offset %d: %q, want offset %d containing %q", tt.expr, eerr.Offset, eerr.Msg, tt.offset, tt.msg)
+		}
+	}
+}
+
+func TestExprErrorLongExpr(t *testing.T) {
+	expr := "1" + strings.Repeat("<<10000", 2000)
+	_, err := Eval(expr)
+	if err == nil {
+		t.Fatal("Eval of 2000 chained shifts succeeded")
+	}
+	if msg := err.Error(); len(msg) > 200 || !strings.Contains(msg, `..."`) {
+		t.Errorf("error for a long expression = %.300s, want it abbreviated", msg)
+	}
+}
+
+func TestFormatExactLargeDenominator(t *testing.T) {
+	// 1e-10000 has ten thousand each of 2 and 5 in its denominator; counting them one at a time took
+	// seconds.
+	x, err := Eval("1e-10000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := FormatExact(x); len(s) != 10002 || !strings.HasPrefix(s, "0.000") || !strings.HasSuffix(s, "01") {
+		t.Errorf("FormatExact(1e-10000) = %.20s... (%d bytes)", s, len(s))
+	}
+
+	third := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Mul(big.NewInt(3), new(big.Int).Exp(big.NewInt(10), big.NewInt(5000), nil)))
+	if s := FormatExact(third); !strings.HasPrefix(s, "1/3000") {
+		t.Errorf("FormatExact(1/(3*10^5000)) = %.20s..., want a fraction", s)
+	}
+}
+
+func TestNeedExact(t *testing.T) {
+	exact := new(big.Int).Lsh(big.NewInt(1), 100)
+	if got, want := NeedIntExact(exact).String(), "12676506002282294014967032053761"; got != want {
+		t.Errorf("NeedIntExact(big) = %s, want %s", got, want)
+	}
+
+	// the exact result rounds to what NeedFloat computes in float64.
+	f, err := ToFloat64(NeedFloatExact(new(big.Rat).SetInt(exact)))
+	if err != nil || f != NeedFloat(Big) {
+		t.Errorf("ToFloat64(NeedFloatExact(big)) = %v, %v, want %v", f, err, NeedFloat(Big))
+	}
+	if got := NeedIntExact(big.NewInt(Small)).Int64(); got != int64(NeedInt(Small)) {
+		t.Errorf("NeedIntExact(small) = %d, want %d", got, NeedInt(Small))
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		expr string
+		to   Type
+		want any
+		err  error
+	}{
+		{"small * 10 + 1", Int, 21, nil},
+		{"1<<63 - 1", Int, 9223372036854775807, nil},
+		{"-1 << 63", Int, -9223372036854775808, nil},
+		{"1 << 63", Int, nil, ErrOverflow},
+		{"big * 10 + 1", Int, nil, ErrOverflow},
+		{"5 / 2.0", Int, nil, ErrTruncated},
+		{"4 / 2.0", Int, 2, nil},
+		{"big / 10.0", Float64, 1.2676506002282295e+29, nil},
+		{"1 << 1024", Float64, nil, ErrOverflow},
+		{"1 << 1023", Float64, 8.98846567431158e+307, nil},
+		{"big", Exact, "1267650600228229401496703205376", nil},
+	}
+
+	for _, tt := range tests {
+		x, err := Eval(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Convert(x, tt.to)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Convert(%s, %s) error = %v, want %v", tt.expr, tt.to, err, tt.err)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("Convert(%s, %s) = %v, want %v", tt.expr, tt.to, got, tt.want)
+		}
+	}
+
+	errs := map[string]string{
+		"big":       "basics: constant 1267650600228229401496703205376 overflows int",
+		"1 << 2000": "basics: constant 1.14813e+602 overflows int",
+		"1 / 3.0":   "basics: constant 1/3 truncated to integer",
+	}
+	for expr, want := range errs {
+		x, _ := Eval(expr)
+		if _, err := Convert(x, Int); err == nil || err.Error() != want {
+			t.Errorf("Convert(%s, int) error = %v, want %q", expr, err, want)
+		}
+	}
+}
+
+func TestFormatExact(t *testing.T) {
+	tests := map[string]string{
+		"1/4":   "0.25",
+		"-3/40": "-0.075",
+		"2/3":   "2/3",
+		"1/6":   "1/6",
+		"42":    "42",
+	}
+
+	for in, want := range tests {
+		x, _ := new(big.Rat).SetString(in)
+		if got := FormatExact(x); got != want {
+			t.Errorf("FormatExact(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestTypeSet(t *testing.T) {
+	var typ Type
+	if got := typ.String(); got != string(Exact) {
+		t.Errorf("zero Type = %q, want %q", got, Exact)
+	}
+	if err := typ.Set("float64"); err != nil || typ != Float64 {
+		t.Errorf("Set(float64) = %v, type %q", err, typ)
+	}
+	if err := typ.Set("int8"); err == nil {
+		t.Error("Set(int8) succeeded")
+	}
+}
@@ -18,41 +18,17 @@ package main
 // when importing a package, you can refer only to its exported names.
 // any "unexported" names aren't accessible outside the package (like "private" or "protected" in other languages).
 
-// functions can take >= 0 arguments.
-// the function `add` below takes two parameters of type "int".
-// notice the type comes after the variable name.
+// the functions from this section live in the importable "basics" package; this program only
+// demonstrates them.
 
-import "fmt"
+import (
+	"flag"
+	"fmt"
+	"os"
 
-func add(x int, y int) int {
-	return x + y
-}
-
-// when two or more consecutive parameters share the same type, you can omit the
-// type from all but the last parameter. See the function `subtract` below.
-
-func subtract(x, y int) int {
-	return x - y
-}
-
-// a function can return any number of results. The function `swap` below retturns two strings.
-func swap(x, y string) (string, string) {
-	return y, x
-}
-
-// return values can be named. If so, they are treated as variables defined at the top of the function.
-// these names should be used to document the meaning of the return values.
-// a return statement without arguments returns the named return values and is called a
-// "naked" return.
-// naked return statements should only be used in short functions, as in the example below;
-// they can harm readability in longer functions.
-
-func split(sum int) (x, y int) {
-	x = sum * 4 / 9
-	y = sum - x
-
-	return
-}
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/01-Basics/basics"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
 
 // the var statement declares a list of variables; the type is last.
 // a var statement can be at the package or function level.
@@ -108,35 +84,42 @@ var packageLevelVar int // declared at package level
 /*
  * Numeric constants are high-precision values.
  * An untyped constant takes the type needed by its context.
+ * See basics.Big and basics.Small.
  */
-const (
-	big   = 1 << 100  // shift left 1 by 100 places (binary number with 1 followed by 100 zeros)
-	small = big >> 99 // shift it right again 99 places, resulting in 2 (10, or 1 << 1).
-)
 
-func needInt(x int) int {
-	return x*10 + 1
-}
-
-func needFloat(x float64) float64 {
-	return x * 0.1
-}
+// pass -format=json to print every example as a structured report.Record instead of text.
+var format report.Format = report.Text
 
 func main() {
-	fmt.Println(add(42, 13))      // add function
-	fmt.Println(subtract(42, 13)) // subtract function
+	flag.Var(&format, "format", "output format: text or json")
+	flag.Parse()
+
+	out := report.NewPrinter(os.Stdout, format)
+
+	sum := basics.Add(42, 13) // add function
+	out.Println(report.Record{Name: "add", Inputs: map[string]any{"x": 42, "y": 13}, Result: sum}, sum)
+
+	diff := basics.Subtract(42, 13) // subtract function
+	out.Println(report.Record{Name: "subtract", Inputs: map[string]any{"x": 42, "y": 13}, Result: diff}, diff)
+
+	a, b := basics.Swap("hello", "world") // swap function
+	out.Println(report.Record{
+		Name:   "swap",
+		Inputs: map[string]any{"x": "hello", "y": "world"},
+		Result: []any{a, b},
+		Type:   "(string, string)",
+	}, a, b)
 
-	a, b := swap("hello", "world") // swap function
-	fmt.Println(a, b)
+	x, y := basics.Split(17) // split function
+	out.Println(report.Record{Name: "split", Inputs: map[string]any{"sum": 17}, Result: []any{x, y}, Type: "(int, int)"}, x, y)
 
-	fmt.Println(split(17))       // split function
-	fmt.Println(packageLevelVar) // package level variable
+	out.Println(report.Record{Name: "package level variable", Result: packageLevelVar}, packageLevelVar)
 
-	var functionLevelVar = 42     // declared and initialized at function level
-	fmt.Println(functionLevelVar) // function level variable
+	var functionLevelVar = 42 // declared and initialized at function level
+	out.Println(report.Record{Name: "function level variable", Result: functionLevelVar}, functionLevelVar)
 
 	short := "short variable declaration" // short variable declaration
-	fmt.Println(short)
+	out.Println(report.Record{Name: "short variable declaration", Result: short}, short)
 
 	// data types
 	var (
@@ -144,16 +127,20 @@ func main() {
 		maxInt uint       = 1<<64 - 1
 		z      complex128 = complex(1, 2)
 	)
-	fmt.Printf("Type: %T Value: %v\n", toBe, toBe)
-	fmt.Printf("Type: %T Value: %v\n", maxInt, maxInt)
-	fmt.Printf("Type: %T Value: %v\n", z, z)
+	out.Printf(report.Record{Name: "bool", Result: toBe}, "Type: %T Value: %v\n", toBe, toBe)
+	out.Printf(report.Record{Name: "uint", Result: maxInt}, "Type: %T Value: %v\n", maxInt, maxInt)
+	out.Printf(report.Record{Name: "complex128", Result: z}, "Type: %T Value: %v\n", z, z)
 
 	// uninitialized variables
 	var i int
 	var f float64
 	var boolean bool
 	var s string
-	fmt.Printf("Zero values - int: %d, float64: %f, bool: %t, string: '%s'\n", i, f, boolean, s)
+	out.Printf(report.Record{
+		Name:   "zero values",
+		Result: map[string]any{"int": i, "float64": f, "bool": boolean, "string": s},
+		Type:   "int, float64, bool, string",
+	}, "Zero values - int: %d, float64: %f, bool: %t, string: '%s'\n", i, f, boolean, s)
 
 	// type conversion
 	var j int = 42
@@ -165,8 +152,18 @@ func main() {
 	floating2 := float64(k)
 	u2 := uint(floating2)
 
-	fmt.Printf("Type conversion - int: %d, float64: %f, uint: %d\n", j, floating, u)
-	fmt.Printf("Simplified type conversion - int: %d, float64: %f, uint: %d\n", k, floating2, u2)
+	out.Printf(report.Record{
+		Name:   "type conversion",
+		Inputs: map[string]any{"int": j},
+		Result: map[string]any{"float64": floating, "uint": u},
+		Type:   "float64, uint",
+	}, "Type conversion - int: %d, float64: %f, uint: %d\n", j, floating, u)
+	out.Printf(report.Record{
+		Name:   "simplified type conversion",
+		Inputs: map[string]any{"int": k},
+		Result: map[string]any{"float64": floating2, "uint": u2},
+		Type:   "float64, uint",
+	}, "Simplified type conversion - int: %d, float64: %f, uint: %d\n", k, floating2, u2)
 
 	// right-hand typed
 	var m int = 27
@@ -177,19 +174,40 @@ func main() {
 	p := 3.142        // p is a float64
 	g := 0.867 + 0.5i // g is a complex128
 
-	fmt.Printf("Right-hand typed - m: %d, n: %d\n", m, n)
-	fmt.Printf("Right-hand untyped - q: %d, p: %f, g: %v\n", q, p, g)
+	out.Printf(report.Record{
+		Name:   "right-hand typed",
+		Inputs: map[string]any{"m": m},
+		Result: n,
+	}, "Right-hand typed - m: %d, n: %d\n", m, n)
+	out.Printf(report.Record{
+		Name:   "right-hand untyped",
+		Result: map[string]any{"q": q, "p": p, "g": g},
+		Type:   fmt.Sprintf("%T, %T, %T", q, p, g),
+	}, "Right-hand untyped - q: %d, p: %f, g: %v\n", q, p, g)
 
 	// constants
 	const hello = "Hello, World!"
-	fmt.Println(hello)
+	out.Println(report.Record{Name: "string constant", Result: hello}, hello)
 
 	const truth = true
-	fmt.Println("Go is a language?", truth)
+	out.Println(report.Record{Name: "boolean constant", Result: truth}, "Go is a language?", truth)
 
 	// numeric constants
-	fmt.Println(needInt(small))
-	// fmt.Println(needInt(big)) // needInt(big) would cause an error: the constant overflows int
-	fmt.Println(needFloat(small))
-	fmt.Println(needFloat(big))
+	smallInt := basics.NeedInt(basics.Small)
+	out.Println(report.Record{Name: "needInt", Inputs: map[string]any{"x": "small"}, Result: smallInt}, smallInt)
+	// basics.NeedInt(basics.Big) would cause an error: the constant overflows int
+	smallFloat := basics.NeedFloat(basics.Small)
+	out.Println(report.Record{Name: "needFloat", Inputs: map[string]any{"x": "small"}, Result: smallFloat}, smallFloat)
+	bigFloat := basics.NeedFloat(basics.Big)
+	out.Println(report.Record{Name: "needFloat", Inputs: map[string]any{"x": "big"}, Result: bigFloat}, bigFloat)
+
+	// math/big can do the compiler's exact arithmetic at run time; converting the result back to an int
+	// is where the overflow shows up.
+	exact, _ := basics.Eval("big")
+	bigInt, _ := basics.Integer(exact)
+	exactInt := basics.NeedIntExact(bigInt)
+	out.Println(report.Record{Name: "needInt exact", Inputs: map[string]any{"x": "big"}, Result: exactInt.String()}, exactInt)
+	if _, err := basics.ToInt(exactInt); err != nil {
+		out.Println(report.Record{Name: "needInt overflow", Inputs: map[string]any{"x": "big"}, Result: err.Error()}, err)
+	}
 }
@@ -0,0 +1,24 @@
+package flowcontrol
+
+import "time"
+
+// Clock reports the current time. Functions that depend on "now" take a Clock so tests and demos can pin it.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by time.Now.
+type SystemClock struct{}
+
+// Now returns the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a Clock that always reports the same instant.
+type FixedClock time.Time
+
+// Now returns the instant c was fixed to.
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
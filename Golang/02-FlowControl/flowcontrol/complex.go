@@ -0,0 +1,141 @@
+package flowcontrol
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strings"
+)
+
+// ComplexSqrt returns the principal square root of x as a complex number: sqrt(x) for x >= 0 and
+// sqrt(-x)i for x < 0. Unlike Sqrt, the result can be used in further arithmetic.
+func ComplexSqrt(x float64) complex128 {
+	if x < 0 {
+		return complex(0, math.Sqrt(-x))
+	}
+
+	return complex(math.Sqrt(x), 0)
+}
+
+// FormatComplex writes z the way Sqrt always has: a real number on its own ("3"), an imaginary one as
+// its coefficient followed by "i" ("2i"), and anything else as both parts ("1+1.7320508075688772i").
+func FormatComplex(z complex128) string {
+	re, im := real(z), imag(z)
+	switch {
+	case im == 0:
+		return fmt.Sprint(re)
+	case re == 0:
+		return fmt.Sprint(im) + "i"
+	}
+
+	s := fmt.Sprint(im)
+	if !strings.HasPrefix(s, "-") && !strings.HasPrefix(s, "+") {
+		s = "+" + s
+	}
+
+	return fmt.Sprint(re) + s + "i"
+}
+
+// ErrRootDegree is returned for roots of degree less than 1.
+var ErrRootDegree = errors.New("flowcontrol: root degree must be at least 1")
+
+// RootMode selects which n-th roots to return.
+type RootMode string
+
+const (
+	Principal RootMode = "principal" // the root at angle arg(z)/n
+	AllRoots  RootMode = "all"       // all n roots, principal first, counterclockwise
+)
+
+// String returns the name of the mode.
+func (m *RootMode) String() string {
+	if m == nil || *m == "" {
+		return string(Principal)
+	}
+
+	return string(*m)
+}
+
+// Set parses s as a RootMode, so a RootMode can be bound to a flag.
+func (m *RootMode) Set(s string) error {
+	switch RootMode(s) {
+	case Principal, AllRoots:
+		*m = RootMode(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown root mode %q (want %s or %s)", s, Principal, AllRoots)
+	}
+}
+
+// Root returns the principal n-th root of z: |z|^(1/n) at angle arg(z)/n, where arg(z) is in (-π, π].
+// For n = 2 it is cmplx.Sqrt(z), so Root(-4, 2) is exactly 2i.
+func Root(z complex128, n int) (complex128, error) {
+	roots, err := Roots(z, n, Principal)
+	if err != nil {
+		return 0, err
+	}
+
+	return roots[0], nil
+}
+
+// Roots returns the n-th roots of z selected by mode: just the principal root, or all n of them spaced
+// 2π/n apart, starting with the principal root and going counterclockwise. The roots of 0 are all 0.
+//
+// cos and sin of multiples of π/2 aren't exactly 0 in floating point, so parts smaller than 1e-15 of a
+// root's magnitude are rounded to 0; that way the cube roots of -8 include exactly -2.
+func Roots(z complex128, n int, mode RootMode) ([]complex128, error) {
+	if n < 1 {
+		return nil, ErrRootDegree
+	}
+
+	count := n
+	switch mode {
+	case Principal, "":
+		count = 1
+	case AllRoots:
+	default:
+		return nil, fmt.Errorf("flowcontrol: unknown root mode %q", string(mode))
+	}
+
+	roots := make([]complex128, count)
+	switch {
+	case n == 1:
+		roots[0] = z
+	case n == 2:
+		roots[0] = cmplx.Sqrt(z)
+		if count == 2 {
+			roots[1] = -roots[0]
+		}
+	default:
+		// 1/n is rounded, so math.Pow can miss by an ulp (8^(1/3) comes out as 1.9999999999999998); one
+		// Newton step on r^n = |z| fixes that.
+		abs := cmplx.Abs(z)
+		r := math.Pow(abs, 1/float64(n))
+		if r > 0 && !math.IsInf(r, 0) {
+			r -= (math.Pow(r, float64(n)) - abs) / (float64(n) * math.Pow(r, float64(n-1)))
+		}
+		theta := cmplx.Phase(z)
+		for k := range roots {
+			roots[k] = snap(cmplx.Rect(r, (theta+2*math.Pi*float64(k))/float64(n)))
+		}
+	}
+
+	return roots, nil
+}
+
+// snap rounds to 0 either part of z that is negligible next to its magnitude.
+func snap(z complex128) complex128 {
+	const eps = 1e-15
+
+	re, im := real(z), imag(z)
+	abs := cmplx.Abs(z)
+	if math.Abs(re) < eps*abs {
+		re = 0
+	}
+	if math.Abs(im) < eps*abs {
+		im = 0
+	}
+
+	return complex(re, im)
+}
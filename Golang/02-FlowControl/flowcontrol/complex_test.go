@@ -0,0 +1,127 @@
+package flowcontrol
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestSqrtDisplay(t *testing.T) {
+	tests := map[float64]string{
+		2:            "1.4142135623730951",
+		-4:           "2i",
+		-2:           "1.4142135623730951i",
+		9:            "3",
+		0:            "0",
+		math.Inf(-1): "+Infi",
+	}
+
+	for x, want := range tests {
+		if got := Sqrt(x); got != want {
+			t.Errorf("Sqrt(%g) = %q, want %q", x, got, want)
+		}
+	}
+}
+
+func TestComplexSqrt(t *testing.T) {
+	if got := ComplexSqrt(-4); got != 2i {
+		t.Errorf("ComplexSqrt(-4) = %v, want 2i", got)
+	}
+	if got := ComplexSqrt(9); got != 3 {
+		t.Errorf("ComplexSqrt(9) = %v, want 3", got)
+	}
+
+	// unlike the string, the result squares back to x.
+	if got := ComplexSqrt(-2) * ComplexSqrt(-2); cmplx.Abs(got-(-2)) > 1e-15 {
+		t.Errorf("ComplexSqrt(-2)^2 = %v, want -2", got)
+	}
+}
+
+func TestFormatComplex(t *testing.T) {
+	tests := []struct {
+		z    complex128
+		want string
+	}{
+		{3, "3"},
+		{2i, "2i"},
+		{-2i, "-2i"},
+		{1 + 2i, "1+2i"},
+		{1 - 2i, "1-2i"},
+		{-0.5 + 0.25i, "-0.5+0.25i"},
+		{complex(1, math.Inf(1)), "1+Infi"},
+		{0, "0"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatComplex(tt.z); got != tt.want {
+			t.Errorf("FormatComplex(%v) = %q, want %q", tt.z, got, tt.want)
+		}
+	}
+}
+
+func TestRoots(t *testing.T) {
+	tests := []struct {
+		z    complex128
+		n    int
+		want []complex128
+	}{
+		{-4, 2, []complex128{2i, -2i}},
+		{4, 2, []complex128{2, -2}},
+		{-8, 3, []complex128{complex(1, math.Sqrt(3)), -2, complex(1, -math.Sqrt(3))}},
+		{16, 4, []complex128{2, 2i, -2, -2i}},
+		{1i, 1, []complex128{1i}},
+		{0, 3, []complex128{0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		got, err := Roots(tt.z, tt.n, AllRoots)
+		if err != nil {
+			t.Fatalf("Roots(%v, %d): %v", tt.z, tt.n, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("Roots(%v, %d) = %v, want %v", tt.z, tt.n, got, tt.want)
+		}
+		for i := range got {
+			if cmplx.Abs(got[i]-tt.want[i]) > 1e-15*max(1, cmplx.Abs(tt.want[i])) {
+				t.Errorf("Roots(%v, %d)[%d] = %v, want %v", tt.z, tt.n, i, got[i], tt.want[i])
+			}
+			// every root raised to the n-th power gives z back.
+			if p := cmplx.Pow(got[i], complex(float64(tt.n), 0)); tt.z != 0 && cmplx.Abs(p-tt.z) > 1e-12*cmplx.Abs(tt.z) {
+				t.Errorf("Roots(%v, %d)[%d]^%d = %v", tt.z, tt.n, i, tt.n, p)
+			}
+		}
+
+		principal, err := Root(tt.z, tt.n)
+		if err != nil || principal != got[0] {
+			t.Errorf("Root(%v, %d) = %v, %v, want %v", tt.z, tt.n, principal, err, got[0])
+		}
+	}
+
+	// the parts that should be zero are exactly zero, so they display cleanly.
+	if roots, _ := Roots(-8, 3, AllRoots); FormatComplex(roots[1]) != "-2" {
+		t.Errorf("middle cube root of -8 displays as %q, want -2", FormatComplex(roots[1]))
+	}
+	if r, _ := Root(-4, 2); FormatComplex(r) != "2i" {
+		t.Errorf("Root(-4, 2) displays as %q, want 2i", FormatComplex(r))
+	}
+
+	if _, err := Roots(8, 0, AllRoots); err != ErrRootDegree {
+		t.Errorf("Roots(8, 0) error = %v, want ErrRootDegree", err)
+	}
+	if _, err := Roots(8, 3, "some"); err == nil {
+		t.Error("Roots with an unknown mode succeeded")
+	}
+}
+
+func TestRootModeSet(t *testing.T) {
+	var m RootMode
+	if got := m.String(); got != string(Principal) {
+		t.Errorf("zero RootMode = %q, want %q", got, Principal)
+	}
+	if err := m.Set("all"); err != nil || m != AllRoots {
+		t.Errorf("Set(all) = %v, mode %q", err, m)
+	}
+	if err := m.Set("some"); err == nil {
+		t.Error("Set(some) succeeded")
+	}
+}
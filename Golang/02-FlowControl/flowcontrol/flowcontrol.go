@@ -0,0 +1,146 @@
+// Package flowcontrol holds the functions from the "Flow Control" section of the Go tour
+// so they can be imported and reused outside of the lesson's demo program.
+package flowcontrol
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/02-FlowControl/roots"
+)
+
+// Sqrt returns the square root of x formatted as a string; negative inputs get an "i" suffix. It is
+// FormatComplex(ComplexSqrt(x)); use ComplexSqrt for a result you can compute with.
+func Sqrt(x float64) string {
+	return FormatComplex(ComplexSqrt(x))
+}
+
+// Pow returns x**n if it is less than lim, and lim otherwise. It prints nothing; use ClampPow to find out
+// whether the limit was hit, or to set a lower bound as well.
+func Pow(x, n, lim float64) float64 {
+	if v := math.Pow(x, n); v < lim {
+		return v
+	}
+	// can't use v here though
+
+	return lim
+}
+
+/*
+ * Exercise: Given a number x, find the number z for which z*z is most nearly x.
+ */
+
+// Nmsqrt approximates the square root of x using Newton's method. It returns NaN for negative x and NaN;
+// use NewtonSqrt to find out why.
+func Nmsqrt(x float64) float64 {
+	r, err := NewtonSqrt(x)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return r.Root
+}
+
+// Limits on NewtonSqrt's iteration. The tolerance is relative to the root, a few units in the last place
+// of a float64; a fixed absolute tolerance like 1e-14 is finer than float64 can resolve once the root
+// passes about 100, and the loop never ends.
+const (
+	SqrtTolerance = 1e-15
+	SqrtMaxIter   = 50
+)
+
+var (
+	ErrNegative = errors.New("flowcontrol: square root of a negative number")
+	ErrNaN      = errors.New("flowcontrol: square root of NaN")
+)
+
+// NewtonSqrt returns the square root of x found with Newton's method, along with the iterations it took
+// and the size of the last step. It returns ErrNegative for x < 0 and ErrNaN for NaN; ±0 and +Inf are
+// their own square roots.
+//
+// The search always ends: x is split as m * 4^k with m in [0.5, 2), Newton's method finds the square
+// root of m from a starting guess of 1, and the answer is scaled back by 2^k. Working on m keeps z*z from
+// overflowing or underflowing, so the relative tolerance is reached in a handful of steps, and the
+// iteration is capped at SqrtMaxIter regardless.
+func NewtonSqrt(x float64) (roots.Result, error) {
+	return newtonSqrt(x, nil)
+}
+
+// newtonSqrt is NewtonSqrt, calling trace (if not nil) with each iterate scaled back to x's magnitude.
+func newtonSqrt(x float64, trace func(roots.Result)) (roots.Result, error) {
+	switch {
+	case math.IsNaN(x):
+		return roots.Result{Root: math.NaN()}, ErrNaN
+	case x < 0:
+		return roots.Result{Root: math.NaN()}, ErrNegative
+	case x == 0 || math.IsInf(x, 1):
+		return roots.Result{Root: x}, nil
+	}
+
+	m, exp := math.Frexp(x)
+	if exp%2 != 0 {
+		m, exp = m*2, exp-1
+	}
+
+	opts := roots.Options{Tolerance: SqrtTolerance, MaxIter: SqrtMaxIter}
+	if trace != nil {
+		opts.Trace = func(r roots.Result) {
+			z := math.Ldexp(r.Root, exp/2)
+			trace(roots.Result{Root: z, Iterations: r.Iterations, Error: math.Ldexp(r.Error, exp/2), Residual: math.Abs(z*z - x)})
+		}
+	}
+	r, err := roots.Newton(
+		func(z float64) float64 { return z*z - m },
+		func(z float64) float64 { return 2 * z },
+		1, opts)
+
+	// the tolerance leaves the root a unit or two in the last place out; step to whichever neighbouring
+	// float64 has the smallest residual. FMA computes z*z - m with a single rounding, so the comparison is
+	// exact enough to pick the correctly rounded root.
+	for z := r.Root; ; {
+		for _, c := range []float64{math.Nextafter(z, 0), math.Nextafter(z, 2)} {
+			if math.Abs(math.FMA(c, c, -m)) < math.Abs(math.FMA(r.Root, r.Root, -m)) {
+				r.Root = c
+			}
+		}
+		if r.Root == z {
+			break
+		}
+		z = r.Root
+	}
+
+	r.Root = math.Ldexp(r.Root, exp/2)
+	r.Error = math.Ldexp(r.Error, exp/2)
+	r.Residual = math.Abs(r.Root*r.Root - x)
+
+	return r, err
+}
+
+// WhenIsSaturday describes how far away Saturday is from the current day on c, in time zone loc.
+func WhenIsSaturday(c Clock, loc *time.Location) string {
+	switch today := c.Now().In(loc).Weekday(); today {
+	case time.Saturday:
+		return "today!!!"
+	case time.Saturday - 1:
+		return "tomorrow!"
+	case time.Saturday - 2:
+		return "in two days."
+	default:
+		return "too far away :("
+	}
+}
+
+// Greeting returns a greeting suited to the current time of day on c, in time zone loc.
+func Greeting(c Clock, loc *time.Location) string {
+	// switch without a condition
+	t := c.Now().In(loc)
+	switch {
+	case t.Hour() < 12:
+		return "Good morning!"
+	case t.Hour() < 17:
+		return "Good afternoon."
+	default:
+		return "Good evening."
+	}
+}
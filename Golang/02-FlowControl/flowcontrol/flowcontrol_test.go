@@ -0,0 +1,144 @@
+package flowcontrol
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWhenIsSaturday(t *testing.T) {
+	tests := []struct {
+		now  string
+		want string
+	}{
+		{"2026-01-17T10:00:00Z", "today!!!"},        // Saturday
+		{"2026-01-16T10:00:00Z", "tomorrow!"},       // Friday
+		{"2026-01-15T10:00:00Z", "in two days."},    // Thursday
+		{"2026-01-14T10:00:00Z", "too far away :("}, // Wednesday
+		{"2026-01-18T10:00:00Z", "too far away :("}, // Sunday
+	}
+
+	for _, tt := range tests {
+		if got := WhenIsSaturday(fixed(t, tt.now), time.UTC); got != tt.want {
+			t.Errorf("WhenIsSaturday(%s) = %q, want %q", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestWhenIsSaturdayTimeZone(t *testing.T) {
+	// 02:00 Saturday in UTC is still Friday evening in New York.
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database unavailable:", err)
+	}
+
+	c := fixed(t, "2026-01-17T02:00:00Z")
+	if got := WhenIsSaturday(c, time.UTC); got != "today!!!" {
+		t.Errorf("WhenIsSaturday in UTC = %q, want %q", got, "today!!!")
+	}
+	if got := WhenIsSaturday(c, loc); got != "tomorrow!" {
+		t.Errorf("WhenIsSaturday in New York = %q, want %q", got, "tomorrow!")
+	}
+}
+
+func TestGreeting(t *testing.T) {
+	tests := []struct {
+		now  string
+		want string
+	}{
+		{"2026-01-14T00:00:00Z", "Good morning!"},
+		{"2026-01-14T11:59:59Z", "Good morning!"},
+		{"2026-01-14T12:00:00Z", "Good afternoon."},
+		{"2026-01-14T16:59:59Z", "Good afternoon."},
+		{"2026-01-14T17:00:00Z", "Good evening."},
+		{"2026-01-14T23:59:59Z", "Good evening."},
+	}
+
+	for _, tt := range tests {
+		if got := Greeting(fixed(t, tt.now), time.UTC); got != tt.want {
+			t.Errorf("Greeting(%s) = %q, want %q", tt.now, got, tt.want)
+		}
+	}
+}
+
+// fixed returns a FixedClock pinned to the RFC 3339 time s.
+func fixed(t *testing.T, s string) FixedClock {
+	t.Helper()
+
+	now, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return FixedClock(now)
+}
+
+func TestNewtonSqrt(t *testing.T) {
+	tests := []struct {
+		x    float64
+		want float64
+		err  error
+	}{
+		{2, math.Sqrt2, nil},
+		{9, 3, nil},
+		{16, 4, nil},
+		{0.25, 0.5, nil},
+		{1e300, 1e150, nil}, // 1e-14 is far below float64 resolution here
+		{math.MaxFloat64, 1.3407807929942596e+154, nil}, // z*z would overflow without scaling
+		{5e-324, 2.2227587494850775e-162, nil},          // smallest subnormal
+		{0, 0, nil},
+		{math.Copysign(0, -1), math.Copysign(0, -1), nil},
+		{math.Inf(1), math.Inf(1), nil},
+		{-4, math.NaN(), ErrNegative},
+		{math.Inf(-1), math.NaN(), ErrNegative},
+		{math.NaN(), math.NaN(), ErrNaN},
+	}
+
+	for _, tt := range tests {
+		r, err := NewtonSqrt(tt.x)
+		if err != tt.err {
+			t.Errorf("NewtonSqrt(%g) error = %v, want %v", tt.x, err, tt.err)
+		}
+		if math.Float64bits(r.Root) != math.Float64bits(tt.want) && !(math.IsNaN(r.Root) && math.IsNaN(tt.want)) {
+			t.Errorf("NewtonSqrt(%g) = %g, want %g", tt.x, r.Root, tt.want)
+		}
+		if r.Iterations > SqrtMaxIter {
+			t.Errorf("NewtonSqrt(%g) took %d iterations", tt.x, r.Iterations)
+		}
+	}
+
+	if got := Nmsqrt(-1); !math.IsNaN(got) {
+		t.Errorf("Nmsqrt(-1) = %g, want NaN", got)
+	}
+}
+
+// FuzzNewtonSqrt checks that NewtonSqrt returns for every float64, within its iteration cap, with the
+// correctly rounded square root or the right error.
+func FuzzNewtonSqrt(f *testing.F) {
+	for _, x := range []float64{2, 0, -1, 1e300, 1e-300, 5e-324, math.MaxFloat64, math.Inf(1), math.Inf(-1), math.NaN()} {
+		f.Add(math.Float64bits(x))
+	}
+
+	f.Fuzz(func(t *testing.T, bits uint64) {
+		x := math.Float64frombits(bits)
+		r, err := NewtonSqrt(x)
+		switch {
+		case math.IsNaN(x):
+			if !errors.Is(err, ErrNaN) {
+				t.Fatalf("NewtonSqrt(NaN) error = %v", err)
+			}
+		case x < 0:
+			if !errors.Is(err, ErrNegative) {
+				t.Fatalf("NewtonSqrt(%g) error = %v", x, err)
+			}
+		case err != nil:
+			t.Fatalf("NewtonSqrt(%g): %v", x, err)
+		case r.Root != math.Sqrt(x):
+			t.Fatalf("NewtonSqrt(%g) = %g, want %g", x, r.Root, math.Sqrt(x))
+		}
+		if r.Iterations > SqrtMaxIter {
+			t.Fatalf("NewtonSqrt(%g) took %d iterations", x, r.Iterations)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package flowcontrol
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	ErrBounds           = errors.New("flowcontrol: lower bound must not be above the upper bound")
+	ErrNegativeExponent = errors.New("flowcontrol: integer exponent must not be negative")
+	ErrPowOverflow      = errors.New("flowcontrol: integer power overflows int64")
+)
+
+// Clamp says whether a power was clamped to one of its bounds.
+type Clamp int
+
+const (
+	Unclamped   Clamp = iota
+	ClampedLow        // the power was at or below the lower bound
+	ClampedHigh       // the power was at or above the upper bound
+)
+
+// String returns a human-readable name for c.
+func (c Clamp) String() string {
+	switch c {
+	case Unclamped:
+		return "unclamped"
+	case ClampedLow:
+		return "clamped to the lower bound"
+	case ClampedHigh:
+		return "clamped to the upper bound"
+	default:
+		return fmt.Sprintf("Clamp(%d)", int(c))
+	}
+}
+
+// Bounds are the lowest and highest results ClampPow returns. Use math.Inf for a side with no bound.
+type Bounds struct {
+	Lower, Upper float64
+}
+
+// Unbounded leaves powers as they are.
+var Unbounded = Bounds{Lower: math.Inf(-1), Upper: math.Inf(1)}
+
+// UpTo returns bounds with no lower bound and an upper bound of lim, as Pow uses.
+func UpTo(lim float64) Bounds {
+	return Bounds{Lower: math.Inf(-1), Upper: lim}
+}
+
+func (b Bounds) validate() error {
+	if math.IsNaN(b.Lower) || math.IsNaN(b.Upper) || b.Lower > b.Upper {
+		return ErrBounds
+	}
+
+	return nil
+}
+
+// PowResult is a power and whether it was clamped.
+type PowResult struct {
+	Value   float64 // the result, within the bounds
+	Raw     float64 // x**n before clamping
+	Clamped Clamp
+}
+
+// ClampPow returns x**n clamped to b. As in Pow, a power equal to a finite bound counts as clamped; an
+// infinite bound clamps nothing, so ±Inf and NaN are returned as they are. It returns ErrBounds if b.Lower
+// is above b.Upper or either bound is NaN. It prints nothing; what to say about a clamped result is up to
+// the caller.
+func ClampPow(x, n float64, b Bounds) (PowResult, error) {
+	if err := b.validate(); err != nil {
+		return PowResult{}, err
+	}
+
+	r := PowResult{Raw: math.Pow(x, n)}
+	switch {
+	case r.Raw >= b.Upper && !math.IsInf(b.Upper, 1):
+		r.Value, r.Clamped = b.Upper, ClampedHigh
+	case r.Raw <= b.Lower && !math.IsInf(b.Lower, -1):
+		r.Value, r.Clamped = b.Lower, ClampedLow
+	default:
+		r.Value = r.Raw
+	}
+
+	return r, nil
+}
+
+// IntPow returns x**n exactly, computed by squaring in O(log n) multiplications. It returns
+// ErrNegativeExponent for n < 0 and ErrPowOverflow if the result doesn't fit in an int64.
+func IntPow(x int64, n int) (int64, error) {
+	if n < 0 {
+		return 0, ErrNegativeExponent
+	}
+
+	m, ok := powUint(absInt64(x), n)
+	negative := x < 0 && n%2 == 1
+	switch {
+	case !ok, !negative && m > math.MaxInt64, negative && m > 1<<63:
+		return 0, ErrPowOverflow
+	case negative:
+		return int64(-m), nil // two's complement, so 1<<63 comes out as math.MinInt64
+	default:
+		return int64(m), nil
+	}
+}
+
+// IntBounds are the lowest and highest results ClampIntPow returns. A nil bound is no bound: every int64
+// is a possible power, so there is no value like math.Inf to stand for "unbounded".
+type IntBounds struct {
+	Lower, Upper *int64
+}
+
+// ClampIntPow returns x**n clamped to b, with the same Clamp reporting as ClampPow. A power too large
+// for an int64 is clamped if there is a bound on its side, since it is beyond that bound anyway, and is
+// reported as ErrPowOverflow otherwise. It returns ErrBounds if b.Lower is above b.Upper.
+func ClampIntPow(x int64, n int, b IntBounds) (int64, Clamp, error) {
+	if b.Lower != nil && b.Upper != nil && *b.Lower > *b.Upper {
+		return 0, Unclamped, ErrBounds
+	}
+
+	v, err := IntPow(x, n)
+	if errors.Is(err, ErrPowOverflow) {
+		switch {
+		case x < 0 && n%2 == 1 && b.Lower != nil:
+			return *b.Lower, ClampedLow, nil
+		case (x >= 0 || n%2 == 0) && b.Upper != nil:
+			return *b.Upper, ClampedHigh, nil
+		}
+	}
+
+	switch {
+	case err != nil:
+		return 0, Unclamped, err
+	case b.Upper != nil && v >= *b.Upper:
+		return *b.Upper, ClampedHigh, nil
+	case b.Lower != nil && v <= *b.Lower:
+		return *b.Lower, ClampedLow, nil
+	default:
+		return v, Unclamped, nil
+	}
+}
+
+// absInt64 returns |x| as a uint64, which holds |math.MinInt64| too.
+func absInt64(x int64) uint64 {
+	if x < 0 {
+		return -uint64(x)
+	}
+
+	return uint64(x)
+}
+
+// powUint returns base**n by squaring, or false if it overflows a uint64.
+func powUint(base uint64, n int) (uint64, bool) {
+	result := uint64(1)
+	for n > 0 {
+		if n&1 == 1 {
+			hi, lo := bits.Mul64(result, base)
+			if hi != 0 {
+				return 0, false
+			}
+			result = lo
+		}
+
+		n >>= 1
+		if n > 0 {
+			// base**2 is needed for the remaining bits of n.
+			hi, lo := bits.Mul64(base, base)
+			if hi != 0 {
+				return 0, false
+			}
+			base = lo
+		}
+	}
+
+	return result, true
+}
@@ -0,0 +1,123 @@
+package flowcontrol
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestClampPow(t *testing.T) {
+	tests := []struct {
+		x, n      float64
+		b         Bounds
+		value     float64
+		clamped   Clamp
+		wantError error
+	}{
+		{3, 2, UpTo(10), 9, Unclamped, nil},
+		{3, 3, UpTo(20), 20, ClampedHigh, nil},
+		{3, 2, UpTo(9), 9, ClampedHigh, nil}, // as in Pow, reaching the limit counts
+		{0.5, 3, Bounds{Lower: 0.25, Upper: 1}, 0.25, ClampedLow, nil},
+		{-2, 3, Bounds{Lower: -5, Upper: 5}, -5, ClampedLow, nil},
+		{2, 1024, Unbounded, math.Inf(1), Unclamped, nil},
+		{-2, 1025, UpTo(0), math.Inf(-1), Unclamped, nil},
+		{2, 1024, UpTo(math.MaxFloat64), math.MaxFloat64, ClampedHigh, nil},
+		{2, 10, Unbounded, 1024, Unclamped, nil},
+		{2, 2, Bounds{Lower: 5, Upper: 1}, 0, Unclamped, ErrBounds},
+		{2, 2, Bounds{Lower: math.NaN(), Upper: 1}, 0, Unclamped, ErrBounds},
+	}
+
+	for _, tt := range tests {
+		r, err := ClampPow(tt.x, tt.n, tt.b)
+		if err != tt.wantError || r.Value != tt.value || r.Clamped != tt.clamped {
+			t.Errorf("ClampPow(%g, %g, %+v) = %+v, %v; want %g, %v, %v", tt.x, tt.n, tt.b, r, err, tt.value, tt.clamped, tt.wantError)
+		}
+		if err == nil && r.Raw != math.Pow(tt.x, tt.n) {
+			t.Errorf("ClampPow(%g, %g, %+v).Raw = %g", tt.x, tt.n, tt.b, r.Raw)
+		}
+		if err == nil && math.IsInf(tt.b.Lower, -1) && Pow(tt.x, tt.n, tt.b.Upper) != r.Value {
+			t.Errorf("Pow(%g, %g, %g) = %g, want %g", tt.x, tt.n, tt.b.Upper, Pow(tt.x, tt.n, tt.b.Upper), r.Value)
+		}
+	}
+
+	if r, _ := ClampPow(math.NaN(), 2, UpTo(1)); !math.IsNaN(r.Value) || r.Clamped != Unclamped {
+		t.Errorf("ClampPow(NaN, 2, UpTo(1)) = %+v", r)
+	}
+}
+
+func TestIntPow(t *testing.T) {
+	tests := []struct {
+		x       int64
+		n       int
+		want    int64
+		wantErr error
+	}{
+		{3, 0, 1, nil},
+		{0, 0, 1, nil},
+		{3, 4, 81, nil},
+		{-3, 3, -27, nil},
+		{-3, 4, 81, nil},
+		{3, 39, 4052555153018976267, nil}, // math.Pow(3, 39) is 11 short
+		{3, 40, 0, ErrPowOverflow},
+		{2, 62, 1 << 62, nil},
+		{2, 63, 0, ErrPowOverflow},
+		{-2, 63, math.MinInt64, nil},
+		{-2, 64, 0, ErrPowOverflow},
+		{1, math.MaxInt, 1, nil},
+		{-1, math.MaxInt, -1, nil},
+		{2, -1, 0, ErrNegativeExponent},
+	}
+
+	for _, tt := range tests {
+		got, err := IntPow(tt.x, tt.n)
+		if got != tt.want || err != tt.wantErr {
+			t.Errorf("IntPow(%d, %d) = %d, %v; want %d, %v", tt.x, tt.n, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestClampIntPow(t *testing.T) {
+	bound := func(v int64) *int64 { return &v }
+	tests := []struct {
+		x       int64
+		n       int
+		b       IntBounds
+		want    int64
+		clamped Clamp
+		wantErr error
+	}{
+		{3, 3, IntBounds{bound(0), bound(20)}, 20, ClampedHigh, nil},
+		{3, 2, IntBounds{bound(0), bound(20)}, 9, Unclamped, nil},
+		{-3, 3, IntBounds{bound(-10), bound(10)}, -10, ClampedLow, nil},
+		{10, 30, IntBounds{bound(0), bound(1000)}, 1000, ClampedHigh, nil},               // overflows, but is above the bound anyway
+		{-10, 31, IntBounds{bound(math.MinInt64), nil}, math.MinInt64, ClampedLow, nil},  // overflows below
+		{-2, 63, IntBounds{}, math.MinInt64, Unclamped, nil},                             // no bounds, so nothing to clamp to
+		{-2, 63, IntBounds{Upper: bound(0)}, math.MinInt64, Unclamped, nil},              // only the bound that is set counts
+		{-2, 63, IntBounds{Lower: bound(math.MinInt64)}, math.MinInt64, ClampedLow, nil}, // an explicit bound does
+		{10, 30, IntBounds{Lower: bound(0)}, 0, Unclamped, ErrPowOverflow},               // overflows with no bound above
+		{-10, 31, IntBounds{Upper: bound(0)}, 0, Unclamped, ErrPowOverflow},              // or below
+		{2, 2, IntBounds{bound(5), bound(1)}, 0, Unclamped, ErrBounds},
+		{2, -2, IntBounds{bound(0), bound(1)}, 0, Unclamped, ErrNegativeExponent},
+	}
+
+	for _, tt := range tests {
+		got, clamped, err := ClampIntPow(tt.x, tt.n, tt.b)
+		if got != tt.want || clamped != tt.clamped || !errors.Is(err, tt.wantErr) {
+			t.Errorf("ClampIntPow(%d, %d, %s) = %d, %v, %v; want %d, %v, %v",
+				tt.x, tt.n, formatIntBounds(tt.b), got, clamped, err, tt.want, tt.clamped, tt.wantErr)
+		}
+	}
+}
+
+// formatIntBounds writes b as [lo, hi], with an unset bound as "none".
+func formatIntBounds(b IntBounds) string {
+	s := func(p *int64) string {
+		if p == nil {
+			return "none"
+		}
+		return fmt.Sprint(*p)
+	}
+
+	return "[" + s(b.Lower) + ", " + s(b.Upper) + "]"
+}
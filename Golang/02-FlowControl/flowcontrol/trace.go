@@ -0,0 +1,176 @@
+package flowcontrol
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/02-FlowControl/roots"
+)
+
+// SqrtStep is one iterate of Newton's method, as recorded by NewtonSqrtTrace.
+type SqrtStep struct {
+	Iteration int     // 0 is the starting guess
+	Z         float64 // the estimate of the square root
+	Step      float64 // how far Z moved in this iteration; 0 for the starting guess
+	Residual  float64 // |Z*Z - x|
+	Error     float64 // |Z - math.Sqrt(x)|, the true error
+	RelError  float64 // Error / math.Sqrt(x)
+}
+
+// SqrtTrace records NewtonSqrt converging on the square root of X.
+type SqrtTrace struct {
+	X     float64
+	Root  float64 // the result, which may be a unit in the last place away from the last step
+	Steps []SqrtStep
+}
+
+// NewtonSqrtTrace runs NewtonSqrt on x and records every iterate, comparing each with math.Sqrt. It
+// returns the same errors as NewtonSqrt. ±0 and +Inf are returned without iterating, so their traces
+// have no steps.
+func NewtonSqrtTrace(x float64) (SqrtTrace, error) {
+	t := SqrtTrace{X: x}
+	want := math.Sqrt(x)
+	r, err := newtonSqrt(x, func(r roots.Result) {
+		s := SqrtStep{Iteration: r.Iterations, Z: r.Root, Residual: r.Residual, Error: math.Abs(r.Root - want)}
+		if r.Iterations > 0 {
+			s.Step = r.Error
+		}
+		s.RelError = s.Error / want
+		t.Steps = append(t.Steps, s)
+	})
+	t.Root = r.Root
+
+	return t, err
+}
+
+// TraceFormat is how a SqrtTrace is written. The zero value means no trace.
+type TraceFormat string
+
+const (
+	TraceTable TraceFormat = "table" // aligned columns, one row per iterate
+	TraceCSV   TraceFormat = "csv"   // the same columns as comma-separated values with a header
+	TracePlot  TraceFormat = "plot"  // an ASCII plot of the relative error against the iteration
+)
+
+// String returns the name of the format, or "" for no trace.
+func (f *TraceFormat) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return string(*f)
+}
+
+// Set parses s as a TraceFormat, so a TraceFormat can be bound to a flag.
+func (f *TraceFormat) Set(s string) error {
+	switch TraceFormat(s) {
+	case TraceTable, TraceCSV, TracePlot:
+		*f = TraceFormat(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown trace format %q (want %s, %s or %s)", s, TraceTable, TraceCSV, TracePlot)
+	}
+}
+
+// Write writes t to w in format f, using PlotHeight rows for TracePlot.
+func (t SqrtTrace) Write(w io.Writer, f TraceFormat) error {
+	switch f {
+	case TraceTable:
+		return t.WriteTable(w)
+	case TraceCSV:
+		return t.WriteCSV(w)
+	case TracePlot:
+		return t.Plot(w, PlotHeight)
+	default:
+		return fmt.Errorf("flowcontrol: unknown trace format %q", string(f))
+	}
+}
+
+// traceHeader names the columns of WriteTable and WriteCSV.
+var traceHeader = []string{"iteration", "z", "step", "residual", "error", "rel_error"}
+
+// WriteTable writes t as a table with one row per iterate.
+func (t SqrtTrace) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, strings.Join(traceHeader, "\t")+"\t")
+	for _, s := range t.Steps {
+		fmt.Fprintf(tw, "%d\t%s\t%.3e\t%.3e\t%.3e\t%.3e\t\n", s.Iteration, formatFloat(s.Z), s.Step, s.Residual, s.Error, s.RelError)
+	}
+
+	return tw.Flush()
+}
+
+// WriteCSV writes t as CSV with a header row and every number in full precision.
+func (t SqrtTrace) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write(traceHeader)
+	for _, s := range t.Steps {
+		cw.Write([]string{
+			strconv.Itoa(s.Iteration),
+			formatFloat(s.Z), formatFloat(s.Step), formatFloat(s.Residual), formatFloat(s.Error), formatFloat(s.RelError),
+		})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// formatFloat writes v in the shortest form that reads back as v.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// PlotHeight is the number of rows Write uses for TracePlot.
+const PlotHeight = 9
+
+// plotFloor is the smallest relative error Plot shows: float64 can't resolve much below it, and errors of
+// exactly 0 are drawn there too.
+const plotFloor = -16
+
+// Plot draws the relative error of each iterate on a log scale, height rows tall and one column per
+// iteration, so quadratic convergence shows up as the error's exponent doubling at every step.
+func (t SqrtTrace) Plot(w io.Writer, height int) error {
+	height = max(height, 2)
+
+	// exponents of the relative errors, clamped to the floor.
+	logs := make([]float64, len(t.Steps))
+	top := math.Inf(-1)
+	for i, s := range t.Steps {
+		logs[i] = max(math.Log10(s.RelError), plotFloor)
+		top = max(top, math.Ceil(logs[i]))
+	}
+	top = max(top, plotFloor+1)
+	// math.Ceil(-0.4) is -0, which would print as "-0.0"; adding 0 turns -0 into +0 and leaves every other
+	// value alone.
+	top += 0
+
+	rows := make([][]byte, height)
+	for r := range rows {
+		rows[r] = []byte(strings.Repeat(" ", 3*len(t.Steps)))
+	}
+	for i, lg := range logs {
+		r := int(math.Round((top - lg) / (top - plotFloor) * float64(height-1)))
+		rows[r][3*i+2] = '*'
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "log10(relative error) of nmsqrt(%g) by iteration\n", t.X)
+	for r, row := range rows {
+		label := top - float64(r)*(top-plotFloor)/float64(height-1)
+		fmt.Fprintf(&b, "%6.1f |%s\n", label, strings.TrimRight(string(row), " "))
+	}
+	fmt.Fprintf(&b, "%6s +%s\n", "", strings.Repeat("-", 3*len(t.Steps)))
+	fmt.Fprintf(&b, "%6s  ", "")
+	for _, s := range t.Steps {
+		fmt.Fprintf(&b, "%3d", s.Iteration)
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
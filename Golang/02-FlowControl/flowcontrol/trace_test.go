@@ -0,0 +1,114 @@
+package flowcontrol
+
+import (
+	"encoding/csv"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewtonSqrtTrace(t *testing.T) {
+	tr, err := NewtonSqrtTrace(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := NewtonSqrt(9)
+	if tr.Root != r.Root || len(tr.Steps) != r.Iterations+1 {
+		t.Fatalf("trace of 9: root %g with %d steps, want %g with %d", tr.Root, len(tr.Steps), r.Root, r.Iterations+1)
+	}
+
+	// the starting guess for 9 = 0.5625 * 4^2 is 1 * 2^2, and each Newton step is (z + 9/z) / 2.
+	want := []float64{4, 3.125, 3.0025}
+	for i, z := range want {
+		s := tr.Steps[i]
+		if s.Iteration != i || s.Z != z || s.Error != math.Abs(z-3) || s.RelError != math.Abs(z-3)/3 {
+			t.Errorf("step %d = %+v, want z = %g", i, s, z)
+		}
+	}
+	if tr.Steps[0].Step != 0 || tr.Steps[1].Step != 0.875 {
+		t.Errorf("steps %g and %g, want 0 for the guess and then 0.875", tr.Steps[0].Step, tr.Steps[1].Step)
+	}
+	if last := tr.Steps[len(tr.Steps)-1]; last.Error != 0 {
+		t.Errorf("last step %+v, want the exact root", last)
+	}
+
+	// quadratic convergence: once close, the relative error at least squares at every step.
+	for i := 2; i < len(tr.Steps); i++ {
+		prev, cur := tr.Steps[i-1].RelError, tr.Steps[i].RelError
+		if cur > 2*prev*prev && cur > 1e-15 {
+			t.Errorf("step %d: relative error %g after %g", i, cur, prev)
+		}
+	}
+
+	if _, err := NewtonSqrtTrace(-1); err != ErrNegative {
+		t.Errorf("NewtonSqrtTrace(-1) error = %v", err)
+	}
+	if tr, _ := NewtonSqrtTrace(0); len(tr.Steps) != 0 || tr.Root != 0 {
+		t.Errorf("NewtonSqrtTrace(0) = %+v", tr)
+	}
+}
+
+func TestTraceWriters(t *testing.T) {
+	tr, _ := NewtonSqrtTrace(2)
+
+	var table strings.Builder
+	if err := tr.Write(&table, TraceTable); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(table.String(), "\n"), "\n")
+	if len(lines) != len(tr.Steps)+1 || !strings.Contains(lines[0], "rel_error") || !strings.Contains(lines[1], "4.142e-01") {
+		t.Errorf("table:\n%s", table.String())
+	}
+
+	var out strings.Builder
+	if err := tr.Write(&out, TraceCSV); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(tr.Steps)+1 || records[0][1] != "z" || records[2][1] != "1.5" {
+		t.Errorf("CSV records %q", records)
+	}
+
+	var plot strings.Builder
+	if err := tr.Plot(&plot, 9); err != nil {
+		t.Fatal(err)
+	}
+	got := plot.String()
+	want := `log10(relative error) of nmsqrt(2) by iteration
+   0.0 |  *
+  -2.0 |     *  *
+  -4.0 |
+  -6.0 |           *
+  -8.0 |
+ -10.0 |
+ -12.0 |              *
+ -14.0 |
+ -16.0 |                 *  *
+       +---------------------
+          0  1  2  3  4  5  6
+`
+	if got != want {
+		t.Errorf("plot:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := tr.Write(&out, "svg"); err == nil {
+		t.Error("Write in an unknown format succeeded")
+	}
+}
+
+func TestTraceFormatSet(t *testing.T) {
+	var f TraceFormat
+	if got := f.String(); got != "" {
+		t.Errorf("zero TraceFormat = %q, want no trace", got)
+	}
+	if err := f.Set("plot"); err != nil || f != TracePlot {
+		t.Errorf("Set(plot) = %v, format %q", err, f)
+	}
+	if err := f.Set("svg"); err == nil {
+		t.Error("Set(svg) succeeded")
+	}
+}
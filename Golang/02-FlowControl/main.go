@@ -1,10 +1,15 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math"
+	"log"
+	"os"
 	"runtime"
 	"time"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/02-FlowControl/flowcontrol"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
 )
 
 /*
@@ -55,49 +60,56 @@ import (
  * Deferred function calls are pushed onto a stack. When a function returns, its deferred calls are executed in last-in-first-out order.
  */
 
-func sqrt(x float64) string {
-	if x < 0 {
-		return sqrt(-x) + "i"
-	}
+/*
+ * The sqrt, pow and nmsqrt functions from this section live in the importable "flowcontrol" package;
+ * this program only demonstrates them.
+ *
+ * Pass -trace=table, -trace=csv or -trace=plot to watch each nmsqrt call converge, iterate by iterate.
+ * With -format=json the iterates are in each nmsqrt record instead.
+ *
+ * The weekday and time-of-day switches read the time from a flowcontrol.Clock. Pass -now (RFC 3339, e.g.
+ * 2026-01-14T19:00:00Z) and/or -tz (an IANA time zone name, e.g. America/New_York) to pin them.
+ */
 
-	return fmt.Sprint(math.Sqrt(x))
-}
+var (
+	nowFlag = flag.String("now", "", "pin the current time (RFC 3339) instead of reading the system clock")
+	tzFlag  = flag.String("tz", "Local", "IANA time zone used by the weekday and time-of-day examples")
 
-func pow(x, n, lim float64) float64 {
-	if v := math.Pow(x, n); v < lim {
-		return v
-	} else {
-		fmt.Printf("%g >= %g\n", v, lim)
-	}
-	// can't use v here though
+	// pass -format=json to print every example as a structured report.Record instead of text.
+	format report.Format = report.Text
 
-	return lim
-}
+	// pass -trace to print every iterate of the nmsqrt exercise.
+	trace flowcontrol.TraceFormat
+)
 
-/*
- * Exercise: Given a number x, find the number z for which z*z is most nearly x.
- */
+func main() {
+	flag.Var(&format, "format", "output format: text or json")
+	flag.Var(&trace, "trace", "show every iterate of nmsqrt: table, csv or plot")
+	flag.Parse()
 
-func nmsqrt(x float64) float64 {
-	z := 1.0 // guess
+	loc, err := time.LoadLocation(*tzFlag)
+	if err != nil {
+		log.Fatalf("invalid -tz: %v", err)
+	}
 
-	// Newton's method
-	for math.Abs(z*z-x) >= 1e-14 {
-		z -= (z*z - x) / (2 * z)
+	var clock flowcontrol.Clock = flowcontrol.SystemClock{}
+	if *nowFlag != "" {
+		now, err := time.Parse(time.RFC3339, *nowFlag)
+		if err != nil {
+			log.Fatalf("invalid -now: %v", err)
+		}
+		clock = flowcontrol.FixedClock(now)
 	}
 
-	// return estimate
-	return z
-}
+	out := report.NewPrinter(os.Stdout, format)
 
-func main() {
 	// basic for loop
 	sum := 0
 	for i := 0; i < 10; i++ {
 		sum += i
 	}
 
-	fmt.Println("Sum from 0 to 9 is:", sum)
+	out.Println(report.Record{Name: "for", Inputs: map[string]any{"from": 0, "to": 9}, Result: sum}, "Sum from 0 to 9 is:", sum)
 
 	// for loop without init and post statements (while loop)
 	sum2 := 1
@@ -105,72 +117,85 @@ func main() {
 		sum2 += sum2
 	}
 
-	fmt.Println(sum2)
+	out.Println(report.Record{Name: "while", Inputs: map[string]any{"start": 1, "limit": 1000}, Result: sum2}, sum2)
 
 	// infinite loop
 	// for {
 	// }
 
 	// if statement function call
-	fmt.Println(sqrt(2))
-	fmt.Println(sqrt(-4))
+	for _, x := range []float64{2, -4} {
+		root := flowcontrol.Sqrt(x)
+		out.Println(report.Record{Name: "sqrt", Inputs: map[string]any{"x": x}, Result: root}, root)
+	}
 
 	// if statement with a short statement function call
-	fmt.Println(pow(3, 2, 10))
-	fmt.Println(pow(3, 3, 20))
+	for _, args := range [][3]float64{{3, 2, 10}, {3, 3, 20}} {
+		r, _ := flowcontrol.ClampPow(args[0], args[1], flowcontrol.UpTo(args[2]))
+		if r.Clamped != flowcontrol.Unclamped {
+			// Pow returns the limit without a word; saying that it was hit is up to the caller.
+			out.Textf("%g >= %g\n", r.Raw, args[2])
+		}
+		out.Println(report.Record{Name: "pow", Inputs: map[string]any{"x": args[0], "n": args[1], "lim": args[2]}, Result: r.Value}, r.Value)
+	}
 
 	// exercise
-	fmt.Println("nmsqrt(2):", nmsqrt(2))
-	fmt.Println("nmsqrt(9):", nmsqrt(9))
-	fmt.Println("nmsqrt(16):", nmsqrt(16))
+	for _, x := range []float64{2, 9, 16} {
+		z := flowcontrol.Nmsqrt(x)
+		if trace == "" {
+			out.Println(report.Record{Name: "nmsqrt", Inputs: map[string]any{"x": x}, Result: z}, fmt.Sprintf("nmsqrt(%g):", x), z)
+			continue
+		}
+
+		t, err := flowcontrol.NewtonSqrtTrace(x)
+		if err != nil {
+			log.Fatalf("tracing nmsqrt(%g): %v", x, err)
+		}
+		// with -format=json the steps go in the record, as a table or plot would break the JSON stream.
+		out.Println(report.Record{
+			Name:   "nmsqrt",
+			Inputs: map[string]any{"x": x, "trace": string(trace)},
+			Result: map[string]any{"root": t.Root, "steps": t.Steps},
+		}, fmt.Sprintf("nmsqrt(%g):", x), z)
+		if format == report.Text {
+			if err := t.Write(os.Stdout, trace); err != nil {
+				log.Fatalf("writing the nmsqrt(%g) trace: %v", x, err)
+			}
+		}
+	}
 
 	// switch statements
-	fmt.Print("Go runs on ")
+	var osName string
 	switch os := runtime.GOOS; os {
 	case "darwin":
-		fmt.Println("OS X.")
+		osName = "OS X"
 	case "linux":
-		fmt.Println("Linux.")
+		osName = "Linux"
 	default:
 		// freebsd, openbsd,
 		// plan9, windows...
-		fmt.Printf("%s.\n", os)
+		osName = os
 	}
+	out.Printf(report.Record{Name: "switch", Inputs: map[string]any{"GOOS": runtime.GOOS}, Result: osName}, "Go runs on %s.\n", osName)
 
-	fmt.Print("Saturday is ")
-	switch today := time.Now().Weekday(); today {
-	case time.Saturday:
-		fmt.Println("today!!!")
-	case time.Saturday - 1:
-		fmt.Println("tomorrow!")
-	case time.Saturday - 2:
-		fmt.Println("in two days.")
-	default:
-		fmt.Println("too far away :(")
-	}
+	saturday := flowcontrol.WhenIsSaturday(clock, loc)
+	out.Println(report.Record{Name: "saturday", Inputs: map[string]any{"now": clock.Now().In(loc)}, Result: saturday}, "Saturday is", saturday)
 
-	// switcg without a condition
-	t := time.Now()
-	switch {
-	case t.Hour() < 12:
-		fmt.Println("Good morning!")
-	case t.Hour() < 17:
-		fmt.Println("Good afternoon.")
-	default:
-		fmt.Println("Good evening.")
-	}
+	// switch without a condition
+	greeting := flowcontrol.Greeting(clock, loc)
+	out.Println(report.Record{Name: "greeting", Inputs: map[string]any{"now": clock.Now().In(loc)}, Result: greeting}, greeting)
 
 	// defer statement
-	defer fmt.Println("World!")
+	defer out.Println(report.Record{Name: "defer", Result: "World!"}, "World!")
 
-	fmt.Print("Hello, ")
+	out.Textf("Hello, ")
 
 	// stacked defers
-	defer fmt.Println("\nDone!")
+	defer out.Println(report.Record{Name: "stacked defers", Result: "Done!"}, "\nDone!")
 	for i := 10; i > 0; i-- {
-		defer fmt.Println(i)
+		defer out.Println(report.Record{Name: "stacked defers", Result: i}, i)
 	}
 
-	defer fmt.Println("Counting...")
+	defer out.Println(report.Record{Name: "stacked defers", Result: "Counting..."}, "Counting...")
 
 }
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/golden"
+)
+
+func TestREADMEOutput(t *testing.T) {
+	// pin the clock to a Wednesday evening so the weekday and greeting lines match the README.
+	golden.Run(t, ".", []string{"-now=2026-01-14T19:00:00Z", "-tz=UTC"},
+		// the OS line depends on where the program runs.
+		golden.Vary("Go runs on Linux.", `^Go runs on .+\.$`),
+	)
+}
@@ -0,0 +1,303 @@
+// Package roots finds zeros of functions of one variable. It generalizes the lesson's Newton's method
+// square root exercise (flowcontrol.Nmsqrt) to any func(float64) float64 and adds the classic
+// alternatives:
+//
+//   - Newton, which needs the derivative and converges fastest near a simple root;
+//   - Secant, which estimates the derivative from the last two points;
+//   - Bisection, which needs an interval where f changes sign and always converges, slowly;
+//   - Brent, which also needs a bracketing interval but mixes bisection with secant and inverse quadratic
+//     steps, so it is both safe and fast.
+//
+// Every method stops once its error estimate is within Options.Tolerance, and gives up with a
+// *ConvergenceError after Options.MaxIter iterations. The Result says how many iterations were used and
+// how far from the root the answer may be.
+package roots
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Defaults used for zero Options fields.
+const (
+	DefaultTolerance = 1e-12
+	DefaultMaxIter   = 100
+)
+
+var (
+	// ErrNoConvergence is wrapped by every *ConvergenceError.
+	ErrNoConvergence = errors.New("roots: did not converge")
+
+	// ErrNoBracket is returned by Bisection and Brent when f(a) and f(b) have the same sign, so the
+	// interval isn't known to contain a root.
+	ErrNoBracket = errors.New("roots: f(a) and f(b) must have opposite signs")
+
+	// ErrFlat is returned by Newton when the derivative is zero and by Secant when the last two points
+	// have the same value, so there is no step to take.
+	ErrFlat = errors.New("roots: function is flat, no step to take")
+)
+
+// Options controls when a method stops. The zero value uses DefaultTolerance and DefaultMaxIter.
+type Options struct {
+	// Tolerance is the largest acceptable error in the root. It is absolute for roots smaller than 1
+	// and relative to the root's magnitude for larger ones, so huge roots can still converge in float64.
+	Tolerance float64
+
+	// MaxIter is the most iterations a method may take before it reports a *ConvergenceError.
+	MaxIter int
+
+	// Trace, if not nil, is called with the current estimate before the first iteration and after each
+	// one, so callers can watch a method converge.
+	Trace func(Result)
+}
+
+func (o Options) trace(r Result) {
+	if o.Trace != nil {
+		o.Trace(r)
+	}
+}
+
+func (o Options) tolerance() float64 {
+	if o.Tolerance > 0 {
+		return o.Tolerance
+	}
+
+	return DefaultTolerance
+}
+
+func (o Options) maxIter() int {
+	if o.MaxIter > 0 {
+		return o.MaxIter
+	}
+
+	return DefaultMaxIter
+}
+
+// within reports whether an error estimate of err is acceptable for a root near x.
+func (o Options) within(err, x float64) bool {
+	return err <= o.tolerance()*max(1, math.Abs(x))
+}
+
+// Result is an approximate root and how it was found.
+type Result struct {
+	Root       float64
+	Iterations int     // iterations used, each costing one or two evaluations of f
+	Error      float64 // estimated distance from Root to the true root: the last step or half the bracket
+	Residual   float64 // |f(Root)|
+}
+
+// ConvergenceError reports a method that ran out of iterations or produced a non-finite point. Result
+// is the best estimate it had when it stopped.
+type ConvergenceError struct {
+	Method string
+	Result Result
+}
+
+func (e *ConvergenceError) Error() string {
+	return fmt.Sprintf("roots: %s did not converge in %d iterations (root %g, error %g)",
+		e.Method, e.Result.Iterations, e.Result.Root, e.Result.Error)
+}
+
+func (e *ConvergenceError) Unwrap() error {
+	return ErrNoConvergence
+}
+
+// finite reports whether x is neither infinite nor NaN.
+func finite(x float64) bool {
+	return !math.IsInf(x, 0) && !math.IsNaN(x)
+}
+
+// Newton finds a root of f by Newton's method, starting from x0. df is the derivative of f.
+func Newton(f, df func(float64) float64, x0 float64, opts Options) (Result, error) {
+	x, fx := x0, f(x0)
+	r := Result{Root: x, Error: math.Inf(1), Residual: math.Abs(fx)}
+	opts.trace(r)
+	for r.Iterations < opts.maxIter() {
+		if fx == 0 {
+			r.Error = 0
+			return r, nil
+		}
+
+		d := df(x)
+		if d == 0 {
+			return r, ErrFlat
+		}
+		step := fx / d
+		x -= step
+		fx = f(x)
+		r.Iterations++
+		if !finite(x) || !finite(fx) {
+			return r, &ConvergenceError{"newton", r}
+		}
+
+		r.Root, r.Error, r.Residual = x, math.Abs(step), math.Abs(fx)
+		opts.trace(r)
+		if opts.within(r.Error, x) {
+			return r, nil
+		}
+	}
+
+	return r, &ConvergenceError{"newton", r}
+}
+
+// Secant finds a root of f by the secant method, starting from the two points x0 and x1.
+func Secant(f func(float64) float64, x0, x1 float64, opts Options) (Result, error) {
+	f0, f1 := f(x0), f(x1)
+	r := Result{Root: x1, Error: math.Abs(x1 - x0), Residual: math.Abs(f1)}
+	opts.trace(r)
+	for r.Iterations < opts.maxIter() {
+		if f1 == 0 {
+			r.Error = 0
+			return r, nil
+		}
+		if f1 == f0 {
+			return r, ErrFlat
+		}
+
+		step := f1 * (x1 - x0) / (f1 - f0)
+		x0, f0 = x1, f1
+		x1 -= step
+		f1 = f(x1)
+		r.Iterations++
+		if !finite(x1) || !finite(f1) {
+			return r, &ConvergenceError{"secant", r}
+		}
+
+		r.Root, r.Error, r.Residual = x1, math.Abs(step), math.Abs(f1)
+		opts.trace(r)
+		if opts.within(r.Error, x1) {
+			return r, nil
+		}
+	}
+
+	return r, &ConvergenceError{"secant", r}
+}
+
+// bracket evaluates f at a and b and checks that they bracket a root. done is true if one of them is a
+// root already, in which case r holds it.
+func bracket(f func(float64) float64, a, b float64) (fa, fb float64, r Result, done bool, err error) {
+	fa, fb = f(a), f(b)
+	switch {
+	case fa == 0:
+		return fa, fb, Result{Root: a}, true, nil
+	case fb == 0:
+		return fa, fb, Result{Root: b}, true, nil
+	case math.Signbit(fa) == math.Signbit(fb) || math.IsNaN(fa) || math.IsNaN(fb):
+		return fa, fb, Result{}, false, ErrNoBracket
+	}
+
+	return fa, fb, Result{}, false, nil
+}
+
+// Bisection finds a root of f in [a, b] by repeatedly halving the interval. f(a) and f(b) must have
+// opposite signs.
+func Bisection(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	fa, _, r, done, err := bracket(f, a, b)
+	if done || err != nil {
+		return r, err
+	}
+
+	for {
+		mid := a + (b-a)/2
+		fm := f(mid)
+		r.Root, r.Error, r.Residual = mid, math.Abs(b-a)/2, math.Abs(fm)
+		if fm == 0 {
+			r.Error = 0
+		}
+		opts.trace(r)
+
+		// stop when the bracket is small enough, or so small that float64 can't split it any further.
+		if fm == 0 || opts.within(r.Error, mid) || mid == a || mid == b {
+			return r, nil
+		}
+		if r.Iterations == opts.maxIter() {
+			return r, &ConvergenceError{"bisection", r}
+		}
+
+		r.Iterations++
+		if math.Signbit(fm) == math.Signbit(fa) {
+			a, fa = mid, fm
+		} else {
+			b = mid
+		}
+	}
+}
+
+// Brent finds a root of f in [a, b] by Brent's method. f(a) and f(b) must have opposite signs. It takes
+// an inverse quadratic interpolation or secant step when that stays inside the bracket and shrinks it
+// quickly enough, and bisects otherwise, so it never does much worse than Bisection.
+func Brent(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	fa, fb, r, done, err := bracket(f, a, b)
+	if done || err != nil {
+		return r, err
+	}
+
+	// b is the best estimate so far, c the other end of the bracket, and a the previous b. d is the last
+	// step and e the one before it.
+	c, fc := b, fb
+	var d, e float64
+	for {
+		if math.Signbit(fb) == math.Signbit(fc) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol := 2*epsilon*math.Abs(b) + 0.5*opts.tolerance()*max(1, math.Abs(b))
+		half := (c - b) / 2
+		r.Root, r.Error, r.Residual = b, math.Abs(half), math.Abs(fb)
+		if fb == 0 {
+			r.Error = 0
+		}
+		opts.trace(r)
+		if math.Abs(half) <= tol || fb == 0 {
+			return r, nil
+		}
+		if r.Iterations == opts.maxIter() {
+			return r, &ConvergenceError{"brent", r}
+		}
+
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// interpolate: secant if only two distinct points are known, inverse quadratic otherwise.
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p, q = 2*half*s, 1-s
+			} else {
+				q = fa / fc
+				t := fb / fc
+				p = s * (2*half*q*(q-t) - (b-a)*(t-1))
+				q = (q - 1) * (t - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+
+			if 2*p < min(3*half*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = half, half
+			}
+		} else {
+			d, e = half, half
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, half)
+		}
+		fb = f(b)
+		r.Iterations++
+	}
+}
+
+// epsilon is the gap between 1 and the next float64.
+const epsilon = 0x1p-52
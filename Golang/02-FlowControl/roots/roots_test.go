@@ -0,0 +1,158 @@
+package roots
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// method runs one root finder on f, with whatever starting points it needs taken from [a, b].
+type method struct {
+	name string
+	find func(f, df func(float64) float64, a, b float64, opts Options) (Result, error)
+}
+
+var methods = []method{
+	{"newton", func(f, df func(float64) float64, a, b float64, opts Options) (Result, error) {
+		return Newton(f, df, b, opts)
+	}},
+	{"secant", func(f, _ func(float64) float64, a, b float64, opts Options) (Result, error) {
+		return Secant(f, a, b, opts)
+	}},
+	{"bisection", func(f, _ func(float64) float64, a, b float64, opts Options) (Result, error) {
+		return Bisection(f, a, b, opts)
+	}},
+	{"brent", func(f, _ func(float64) float64, a, b float64, opts Options) (Result, error) {
+		return Brent(f, a, b, opts)
+	}},
+}
+
+func TestMethods(t *testing.T) {
+	tests := []struct {
+		name  string
+		f, df func(float64) float64
+		a, b  float64
+		want  float64
+	}{
+		{"sqrt 2", func(x float64) float64 { return x*x - 2 }, func(x float64) float64 { return 2 * x }, 0, 2, math.Sqrt2},
+		{"cube root 10", func(x float64) float64 { return x*x*x - 10 }, func(x float64) float64 { return 3 * x * x }, 1, 3, math.Cbrt(10)},
+		{"cos x = x", func(x float64) float64 { return math.Cos(x) - x }, func(x float64) float64 { return -math.Sin(x) - 1 }, 0, 1, 0.7390851332151607},
+		{"exp", func(x float64) float64 { return math.Exp(x) - 5 }, math.Exp, 0, 3, math.Log(5)},
+		{"large root", func(x float64) float64 { return x*x - 1e20 }, func(x float64) float64 { return 2 * x }, 1, 1e12, 1e10},
+	}
+
+	for _, tt := range tests {
+		for _, m := range methods {
+			r, err := m.find(tt.f, tt.df, tt.a, tt.b, Options{})
+			if err != nil {
+				t.Errorf("%s/%s: %v", tt.name, m.name, err)
+				continue
+			}
+			if math.Abs(r.Root-tt.want) > 1e-9*max(1, tt.want) {
+				t.Errorf("%s/%s: root %v, want %v", tt.name, m.name, r.Root, tt.want)
+			}
+			if r.Iterations == 0 || r.Iterations > DefaultMaxIter {
+				t.Errorf("%s/%s: %d iterations", tt.name, m.name, r.Iterations)
+			}
+			if r.Error > DefaultTolerance*max(1, tt.want) {
+				t.Errorf("%s/%s: reported error %g above the tolerance", tt.name, m.name, r.Error)
+			}
+			if want := math.Abs(tt.f(r.Root)); r.Residual != want {
+				t.Errorf("%s/%s: residual %g, want |f(root)| = %g", tt.name, m.name, r.Residual, want)
+			}
+		}
+	}
+}
+
+func TestBrentBeatsBisection(t *testing.T) {
+	f := func(x float64) float64 { return math.Cos(x) - x }
+	bis, _ := Bisection(f, 0, 1, Options{})
+	brent, _ := Brent(f, 0, 1, Options{})
+	if brent.Iterations >= bis.Iterations {
+		t.Errorf("Brent took %d iterations, Bisection %d", brent.Iterations, bis.Iterations)
+	}
+}
+
+func TestNoConvergence(t *testing.T) {
+	// Newton's method cycles between 0 and 1 on x^3 - 2x + 2 from 0.
+	f := func(x float64) float64 { return x*x*x - 2*x + 2 }
+	df := func(x float64) float64 { return 3*x*x - 2 }
+	r, err := Newton(f, df, 0, Options{MaxIter: 20})
+	var cerr *ConvergenceError
+	if !errors.As(err, &cerr) || !errors.Is(err, ErrNoConvergence) {
+		t.Fatalf("Newton on a cycle: err = %v, want a *ConvergenceError", err)
+	}
+	if r.Iterations != 20 || cerr.Result != r || cerr.Method != "newton" {
+		t.Errorf("Newton on a cycle: result %+v, error %+v", r, cerr)
+	}
+
+	// a bracket that tight can't be reached in 5 halvings.
+	sq := func(x float64) float64 { return x*x - 2 }
+	if _, err := Bisection(sq, 0, 2, Options{MaxIter: 5}); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Bisection with MaxIter 5: err = %v", err)
+	}
+	if r, err := Brent(sq, 0, 2, Options{MaxIter: 2}); !errors.Is(err, ErrNoConvergence) || r.Iterations != 2 {
+		t.Errorf("Brent with MaxIter 2: %+v, err = %v", r, err)
+	}
+
+	// x^2 + 1 has no real root, so the secant method wanders off.
+	if _, err := Secant(func(x float64) float64 { return x*x + 1 }, 1, 2, Options{}); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Secant on x^2 + 1: err = %v", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	sq := func(x float64) float64 { return x*x - 2 }
+	if _, err := Bisection(sq, 2, 3, Options{}); err != ErrNoBracket {
+		t.Errorf("Bisection without a sign change: err = %v", err)
+	}
+	if _, err := Brent(sq, -1, 1, Options{}); err != ErrNoBracket {
+		t.Errorf("Brent without a sign change: err = %v", err)
+	}
+	if _, err := Newton(sq, func(x float64) float64 { return 2 * x }, 0, Options{}); err != ErrFlat {
+		t.Errorf("Newton from a stationary point: err = %v", err)
+	}
+	if _, err := Secant(sq, -1, 1, Options{}); err != ErrFlat {
+		t.Errorf("Secant through equal values: err = %v", err)
+	}
+}
+
+func TestExactRoot(t *testing.T) {
+	f := func(x float64) float64 { return x - 3 }
+	for _, m := range methods {
+		r, err := m.find(f, func(float64) float64 { return 1 }, 3, 5, Options{})
+		if err != nil || r.Root != 3 || r.Residual != 0 {
+			t.Errorf("%s with a root at the start: %+v, %v", m.name, r, err)
+		}
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+	loose, _ := Bisection(f, 0, 2, Options{Tolerance: 1e-3})
+	tight, _ := Bisection(f, 0, 2, Options{Tolerance: 1e-12})
+	if loose.Iterations >= tight.Iterations || loose.Error > 1e-3 {
+		t.Errorf("loose tolerance: %+v, tight: %+v", loose, tight)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+	df := func(x float64) float64 { return 2 * x }
+	for _, m := range methods {
+		var steps []Result
+		r, err := m.find(f, df, 0, 2, Options{Trace: func(r Result) { steps = append(steps, r) }})
+		if err != nil {
+			t.Fatalf("%s: %v", m.name, err)
+		}
+
+		if len(steps) != r.Iterations+1 || steps[len(steps)-1] != r {
+			t.Fatalf("%s: traced %d steps ending %+v, want %d ending %+v", m.name, len(steps), steps[len(steps)-1], r.Iterations+1, r)
+		}
+		for i, s := range steps {
+			if s.Iterations != i {
+				t.Errorf("%s: step %d reports iteration %d", m.name, i, s.Iterations)
+			}
+		}
+	}
+}
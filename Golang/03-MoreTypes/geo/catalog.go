@@ -0,0 +1,450 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// Format is a file format for a catalog of named places, a map[string]moretypes.Coord like the lesson's
+// map literals.
+type Format string
+
+const (
+	CSV     Format = "csv"     // a name,lat,long header, then one place per record
+	JSON    Format = "json"    // an object mapping each name to {"lat": ..., "long": ...}
+	GeoJSON Format = "geojson" // a FeatureCollection of Points, each with a "name" property
+)
+
+// String returns the name of the format.
+func (f *Format) String() string {
+	if f == nil || *f == "" {
+		return string(CSV)
+	}
+
+	return string(*f)
+}
+
+// Set parses s as a Format, so a Format can be bound to a flag.
+func (f *Format) Set(s string) error {
+	switch Format(s) {
+	case CSV, JSON, GeoJSON:
+		*f = Format(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q (want %s, %s or %s)", s, CSV, JSON, GeoJSON)
+	}
+}
+
+// FormatOf returns the format for a file name by its extension: .csv, .json, or .geojson.
+func FormatOf(name string) (Format, error) {
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".csv":
+		return CSV, nil
+	case ".json":
+		return JSON, nil
+	case ".geojson":
+		return GeoJSON, nil
+	default:
+		return "", fmt.Errorf("geo: can't tell the format of %q from its extension (want .csv, .json or .geojson)", name)
+	}
+}
+
+// LineError is a problem with one place in a catalog being read.
+type LineError struct {
+	Line int // 1-based line of the input the place starts on
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("geo: line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Read reads a catalog in format f (an empty Format means CSV). If any places are malformed, out of range
+// or duplicated, Read returns every problem, each as a *LineError, joined with errors.Join; the catalog
+// holds the places that were read correctly.
+func Read(r io.Reader, f Format) (map[string]moretypes.Coord, error) {
+	switch f {
+	case CSV, "":
+		return ReadCSV(r)
+	case JSON:
+		return ReadJSON(r)
+	case GeoJSON:
+		return ReadGeoJSON(r)
+	default:
+		return nil, fmt.Errorf("geo: unknown format %q", string(f))
+	}
+}
+
+// Write writes places to w in format f (an empty Format means CSV), sorted by name.
+func Write(w io.Writer, places map[string]moretypes.Coord, f Format) error {
+	switch f {
+	case CSV, "":
+		return WriteCSV(w, places)
+	case JSON:
+		return WriteJSON(w, places)
+	case GeoJSON:
+		return WriteGeoJSON(w, places)
+	default:
+		return fmt.Errorf("geo: unknown format %q", string(f))
+	}
+}
+
+// catalog collects places as they are read, along with the problems found on the way.
+type catalog struct {
+	places map[string]moretypes.Coord
+	lines  map[string]int // where each name was first seen
+	errs   []error
+}
+
+func newCatalog() *catalog {
+	return &catalog{places: map[string]moretypes.Coord{}, lines: map[string]int{}}
+}
+
+// add validates a place read from line and adds it to the catalog, or records why it can't.
+func (c *catalog) add(line int, name string, coord moretypes.Coord) {
+	if err := validate(coord); err != nil {
+		c.fail(line, err)
+		return
+	}
+
+	switch first, dup := c.lines[name]; {
+	case name == "":
+		c.fail(line, errors.New("place has no name"))
+	case dup:
+		c.fail(line, fmt.Errorf("%q is already defined on line %d", name, first))
+	default:
+		c.places[name], c.lines[name] = coord, line
+	}
+}
+
+func (c *catalog) fail(line int, err error) {
+	c.errs = append(c.errs, &LineError{Line: line, Err: err})
+}
+
+func (c *catalog) result() (map[string]moretypes.Coord, error) {
+	return c.places, errors.Join(c.errs...)
+}
+
+// names returns the names in places, sorted.
+func names(places map[string]moretypes.Coord) []string {
+	list := make([]string, 0, len(places))
+	for name := range places {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+
+	return list
+}
+
+// ReadCSV reads a CSV catalog. The first record may be a name,lat,long header; every other record must
+// have exactly those three fields.
+func ReadCSV(r io.Reader) (map[string]moretypes.Coord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	c := newCatalog()
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				c.fail(perr.StartLine, perr.Err)
+				continue
+			}
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		if first && strings.EqualFold(record[0], "name") {
+			continue
+		}
+		if len(record) != 3 {
+			c.fail(line, fmt.Errorf("want 3 fields (name, lat, long), got %d", len(record)))
+			continue
+		}
+
+		lat, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			c.fail(line, fmt.Errorf("latitude %q is not a number", record[1]))
+			continue
+		}
+		long, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil {
+			c.fail(line, fmt.Errorf("longitude %q is not a number", record[2]))
+			continue
+		}
+		c.add(line, strings.TrimSpace(record[0]), moretypes.Coord{Lat: lat, Long: long})
+	}
+
+	return c.result()
+}
+
+// WriteCSV writes places as a CSV catalog with a name,lat,long header.
+func WriteCSV(w io.Writer, places map[string]moretypes.Coord) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"name", "lat", "long"})
+	for _, name := range names(places) {
+		p := places[name]
+		cw.Write([]string{name, strconv.FormatFloat(p.Lat, 'f', -1, 64), strconv.FormatFloat(p.Long, 'f', -1, 64)})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// jsonCoord is a Coord as it appears in a JSON catalog.
+type jsonCoord struct {
+	Lat  *float64 `json:"lat"`
+	Long *float64 `json:"long"`
+}
+
+// coord checks that both fields were present and returns the Coord.
+func (j jsonCoord) coord() (moretypes.Coord, error) {
+	if j.Lat == nil || j.Long == nil {
+		return moretypes.Coord{}, errors.New(`place needs both "lat" and "long"`)
+	}
+
+	return moretypes.Coord{Lat: *j.Lat, Long: *j.Long}, nil
+}
+
+// lineOf returns the 1-based line of the first value at or after offset in data, skipping whitespace and
+// the separators between JSON values.
+func lineOf(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) && strings.IndexByte(" \t\r\n,:", data[i]) >= 0 {
+		i++
+	}
+
+	return 1 + bytes.Count(data[:i], []byte("\n"))
+}
+
+// jsonError turns an error from the decoder into a *LineError where it can find the line.
+func jsonError(data []byte, dec *json.Decoder, err error) error {
+	var serr *json.SyntaxError
+	if errors.As(err, &serr) {
+		return &LineError{Line: lineOf(data, serr.Offset-1), Err: err}
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return &LineError{Line: lineOf(data, int64(len(data))), Err: errors.New("unexpected end of JSON input")}
+	}
+
+	return &LineError{Line: lineOf(data, dec.InputOffset()), Err: err}
+}
+
+// expectDelim reads the next token from dec and checks that it is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim, what string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("want %s, got %v", what, tok)
+	}
+
+	return nil
+}
+
+// ReadJSON reads a JSON catalog: an object mapping each place's name to {"lat": ..., "long": ...}.
+func ReadJSON(r io.Reader) (map[string]moretypes.Coord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if err := expectDelim(dec, '{', "an object of places"); err != nil {
+		return nil, jsonError(data, dec, err)
+	}
+
+	c := newCatalog()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+		}
+		name := tok.(string)
+
+		line := lineOf(data, dec.InputOffset())
+		var j jsonCoord
+		if err := dec.Decode(&j); err != nil {
+			var terr *json.UnmarshalTypeError
+			if !errors.As(err, &terr) {
+				return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+			}
+			c.fail(line, fmt.Errorf("%q: %s must be a number", name, terr.Field))
+			continue
+		}
+		coord, err := j.coord()
+		if err != nil {
+			c.fail(line, fmt.Errorf("%q: %v", name, err))
+			continue
+		}
+		c.add(line, name, coord)
+	}
+
+	if err := expectDelim(dec, '}', "the end of the object"); err != nil {
+		return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+	}
+
+	return c.result()
+}
+
+// WriteJSON writes places as an indented JSON catalog.
+func WriteJSON(w io.Writer, places map[string]moretypes.Coord) error {
+	out := make(map[string]jsonCoord, len(places))
+	for name, p := range places {
+		out[name] = jsonCoord{Lat: &p.Lat, Long: &p.Long}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// feature is a GeoJSON Feature with a Point geometry.
+type feature struct {
+	Type     string `json:"type"`
+	Geometry *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"` // decoded once the geometry is known to be a Point
+	} `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// place checks that f is a named Point and returns its name and coordinate. GeoJSON puts the longitude
+// first.
+func (f feature) place() (string, moretypes.Coord, error) {
+	switch {
+	case f.Type != "Feature":
+		return "", moretypes.Coord{}, fmt.Errorf(`want a "Feature", got %q`, f.Type)
+	case f.Geometry == nil || f.Geometry.Type != "Point":
+		return "", moretypes.Coord{}, errors.New(`feature's geometry must be a "Point"`)
+	}
+
+	var pos []float64
+	if err := json.Unmarshal(f.Geometry.Coordinates, &pos); err != nil || len(pos) < 2 {
+		return "", moretypes.Coord{}, errors.New("point's coordinates must be a longitude and a latitude")
+	}
+
+	name, _ := f.Properties["name"].(string)
+	if name == "" {
+		return "", moretypes.Coord{}, errors.New(`feature has no "name" property`)
+	}
+
+	return name, moretypes.Coord{Lat: pos[1], Long: pos[0]}, nil
+}
+
+// ReadGeoJSON reads a GeoJSON FeatureCollection whose features are Points with a "name" property. Other
+// properties, and members of the collection other than "type" and "features", are ignored.
+func ReadGeoJSON(r io.Reader) (map[string]moretypes.Coord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if err := expectDelim(dec, '{', "a FeatureCollection object"); err != nil {
+		return nil, jsonError(data, dec, err)
+	}
+
+	c := newCatalog()
+	kind := ""
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+		}
+
+		switch tok.(string) {
+		case "type":
+			line := lineOf(data, dec.InputOffset())
+			if err := dec.Decode(&kind); err != nil || kind != "FeatureCollection" {
+				return nil, &LineError{Line: line, Err: errors.New(`"type" must be "FeatureCollection"`)}
+			}
+		case "features":
+			if err := expectDelim(dec, '[', "an array of features"); err != nil {
+				return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+			}
+			for dec.More() {
+				line := lineOf(data, dec.InputOffset())
+				var f feature
+				if err := dec.Decode(&f); err != nil {
+					var terr *json.UnmarshalTypeError
+					if !errors.As(err, &terr) {
+						return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+					}
+					c.fail(line, fmt.Errorf("%s has the wrong type", terr.Field))
+					continue
+				}
+				name, coord, err := f.place()
+				if err != nil {
+					c.fail(line, err)
+					continue
+				}
+				c.add(line, name, coord)
+			}
+			if _, err := dec.Token(); err != nil {
+				return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return c.places, errors.Join(append(c.errs, jsonError(data, dec, err))...)
+			}
+		}
+	}
+
+	if kind == "" {
+		return nil, &LineError{Line: 1, Err: errors.New(`missing "type": "FeatureCollection"`)}
+	}
+
+	return c.result()
+}
+
+// WriteGeoJSON writes places as an indented GeoJSON FeatureCollection of Points.
+func WriteGeoJSON(w io.Writer, places map[string]moretypes.Coord) error {
+	type point struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}
+	type geoFeature struct {
+		Type       string            `json:"type"`
+		Geometry   point             `json:"geometry"`
+		Properties map[string]string `json:"properties"`
+	}
+
+	features := make([]geoFeature, 0, len(places))
+	for _, name := range names(places) {
+		p := places[name]
+		features = append(features, geoFeature{
+			Type:       "Feature",
+			Geometry:   point{Type: "Point", Coordinates: [2]float64{p.Long, p.Lat}},
+			Properties: map[string]string{"name": name},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Type     string       `json:"type"`
+		Features []geoFeature `json:"features"`
+	}{"FeatureCollection", features})
+}
@@ -0,0 +1,164 @@
+package geo
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range []Format{CSV, JSON, GeoJSON} {
+		var buf bytes.Buffer
+		if err := Write(&buf, Cities, f); err != nil {
+			t.Fatalf("%s: Write: %v", f, err)
+		}
+
+		got, err := Read(&buf, f)
+		if err != nil {
+			t.Fatalf("%s: Read: %v", f, err)
+		}
+		if !reflect.DeepEqual(got, Cities) {
+			t.Errorf("%s: round trip = %v, want %v", f, got, Cities)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	places := map[string]moretypes.Coord{
+		"Google":    {Lat: 37.42202, Long: -122.08408},
+		"Bell Labs": {Lat: 40.68433, Long: -74.39967},
+	}
+
+	tests := []struct {
+		f    Format
+		want string
+	}{
+		{CSV, "name,lat,long\nBell Labs,40.68433,-74.39967\nGoogle,37.42202,-122.08408\n"},
+		{JSON, `{
+  "Bell Labs": {
+    "lat": 40.68433,
+    "long": -74.39967
+  },
+  "Google": {
+    "lat": 37.42202,
+    "long": -122.08408
+  }
+}
+`},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, places, tt.f); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s: Write =\n%s\nwant\n%s", tt.f, buf.String(), tt.want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, places); err != nil {
+		t.Fatal(err)
+	}
+	// GeoJSON puts the longitude first.
+	if !strings.Contains(buf.String(), `"coordinates": [
+          -74.39967,
+          40.68433
+        ]`) {
+		t.Errorf("WriteGeoJSON doesn't put longitude first:\n%s", buf.String())
+	}
+}
+
+// lineErrors returns the lines of the *LineErrors joined in err, in order.
+func lineErrors(t *testing.T, err error) []int {
+	t.Helper()
+
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else if err != nil {
+		errs = []error{err}
+	}
+
+	var lines []int
+	for _, e := range errs {
+		var lerr *LineError
+		if !errors.As(e, &lerr) {
+			t.Fatalf("error %q is not a *LineError", e)
+		}
+		lines = append(lines, lerr.Line)
+	}
+
+	return lines
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      Format
+		input  string
+		places []string // the places read despite the errors
+		lines  []int    // the lines reported
+		msg    string   // part of the error message
+	}{
+		{"csv ok without header", CSV, "Chicago,41.87811,-87.62980\n", []string{"Chicago"}, nil, ""},
+		{"csv latitude", CSV, "name,lat,long\nChicago,41.87811,-87.62980\nNowhere,91,0\n", []string{"Chicago"}, []int{3}, "geo: line 3: latitude 91 is outside [-90, 90]"},
+		{"csv longitude", CSV, "Nowhere,0,-180.5\n", nil, []int{1}, "longitude -180.5 is outside"},
+		{"csv not a number", CSV, "name,lat,long\nA,north,0\nB,1,east\n", nil, []int{2, 3}, `latitude "north" is not a number`},
+		{"csv fields", CSV, "A,1\nB,1,2,3\nC,1,2\n", []string{"C"}, []int{1, 2}, "want 3 fields"},
+		{"csv duplicate", CSV, "A,1,2\nB,3,4\nA,5,6\n", []string{"A", "B"}, []int{3}, `"A" is already defined on line 1`},
+		{"csv no name", CSV, " ,1,2\n", nil, []int{1}, "place has no name"},
+		{"csv quotes", CSV, "A,1,2\n\"B,3,4\n", []string{"A"}, []int{2}, "line 2"},
+
+		{"json ok", JSON, `{"A": {"lat": 1, "long": 2}}`, []string{"A"}, nil, ""},
+		{"json range", JSON, "{\n  \"A\": {\"lat\": 1, \"long\": 2},\n  \"B\": {\"lat\": 100, \"long\": 2}\n}", []string{"A"}, []int{3}, "line 3: latitude 100"},
+		{"json missing field", JSON, "{\n\"A\": {\"lat\": 1}\n}", nil, []int{2}, `"A": place needs both "lat" and "long"`},
+		{"json wrong type", JSON, "{\n\"A\": {\"lat\": \"1\", \"long\": 2},\n\"B\": {\"lat\": 1, \"long\": 2}\n}", []string{"B"}, []int{2}, `"A": lat must be a number`},
+		{"json syntax", JSON, "{\n\"A\": {\"lat\": 1, \"long\": 2},\n\"B\": {\"lat\": 1 \"long\": 2}\n}", []string{"A"}, []int{3}, "line 3"},
+		{"json not an object", JSON, "[]", nil, []int{1}, "want an object of places"},
+		{"json truncated", JSON, "{\n\"A\": {\"lat\": 1, \"long\": 2}", []string{"A"}, []int{2}, "unexpected end of JSON input"},
+
+		{"geojson range", GeoJSON, `{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [2, 1]}, "properties": {"name": "A"}},
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [200, 1]}, "properties": {"name": "B"}}
+]}`, []string{"A"}, []int{3}, "longitude 200"},
+		{"geojson not a point", GeoJSON, `{"features": [
+  {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}, "properties": {"name": "A"}},
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [0]}, "properties": {"name": "B"}},
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {}},
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {"name": "D", "pop": 3}}
+], "type": "FeatureCollection", "bbox": [0, 0, 1, 1]}`, []string{"D"}, []int{2, 3, 4}, `geometry must be a "Point"`},
+		{"geojson wrong type", GeoJSON, `{"type": "Feature"}`, nil, []int{1}, `"type" must be "FeatureCollection"`},
+		{"geojson missing type", GeoJSON, `{"features": []}`, nil, []int{1}, `missing "type"`},
+	}
+
+	for _, tt := range tests {
+		got, err := Read(strings.NewReader(tt.input), tt.f)
+
+		if lines := lineErrors(t, err); !reflect.DeepEqual(lines, tt.lines) {
+			t.Errorf("%s: error lines %v, want %v; error: %v", tt.name, lines, tt.lines, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), tt.msg) {
+			t.Errorf("%s: error %q doesn't mention %q", tt.name, err, tt.msg)
+		}
+		if names := names(got); !reflect.DeepEqual(names, tt.places) && (len(names) != 0 || len(tt.places) != 0) {
+			t.Errorf("%s: read %v, want %v", tt.name, names, tt.places)
+		}
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	for name, want := range map[string]Format{"cities.csv": CSV, "a/b.JSON": JSON, "map.geojson": GeoJSON} {
+		if got, err := FormatOf(name); err != nil || got != want {
+			t.Errorf("FormatOf(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := FormatOf("cities.txt"); err == nil {
+		t.Error("FormatOf(cities.txt) succeeded")
+	}
+}
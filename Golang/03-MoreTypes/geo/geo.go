@@ -0,0 +1,219 @@
+// Package geo measures distances and directions between the moretypes.Coord values from the "More Types"
+// lesson's maps, and finds the places in a map[string]Coord nearest to a point.
+//
+// Haversine treats the Earth as a sphere, which is fast and accurate to about 0.5%. Vincenty uses the
+// WGS-84 ellipsoid and is accurate to a millimetre, but its iteration can fail to converge for nearly
+// antipodal points. Distances are in metres and angles in degrees.
+//
+// Catalogs of places can be read from and written to CSV, JSON and GeoJSON files with Read and Write.
+// Nearest, Within and InBox scan every place in a catalog; an Index answers the same queries on large
+// catalogs in logarithmic time.
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// EarthRadius is the mean radius of the Earth in metres, used by Haversine.
+const EarthRadius = 6371008.8
+
+// WGS-84 ellipsoid, used by Vincenty.
+const (
+	wgs84A = 6378137.0         // semi-major axis in metres
+	wgs84F = 1 / 298.257223563 // flattening
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+// ErrNoConvergence is returned by Vincenty when its iteration doesn't converge, which happens for some
+// nearly antipodal points. Haversine is a reasonable fallback.
+var ErrNoConvergence = errors.New("geo: Vincenty formula failed to converge")
+
+// Cities are the places from the lesson's "map literal 2" example.
+var Cities = map[string]moretypes.Coord{
+	"New York City":   {Lat: 40.71278, Long: -74.00594},
+	"Los Angeles":     {Lat: 34.05223, Long: -118.24368},
+	"Chicago":         {Lat: 41.87811, Long: -87.62980},
+	"Houston":         {Lat: 29.76043, Long: -95.36980},
+	"Philadelphia":    {Lat: 39.95233, Long: -75.16379},
+	"Pittsburgh":      {Lat: 40.44062, Long: -79.99589},
+	"San Francisco":   {Lat: 37.77493, Long: -122.41942},
+	"Washington D.C.": {Lat: 38.90719, Long: -77.03687},
+}
+
+// Validate returns an error unless c has a latitude in [-90, 90] and a longitude in [-180, 180].
+func Validate(c moretypes.Coord) error {
+	if err := validate(c); err != nil {
+		return fmt.Errorf("geo: %w", err)
+	}
+
+	return nil
+}
+
+// validate is Validate without the package prefix on the error, for LineError to wrap.
+func validate(c moretypes.Coord) error {
+	switch {
+	case !(c.Lat >= -90 && c.Lat <= 90):
+		return fmt.Errorf("latitude %g is outside [-90, 90]", c.Lat)
+	case !(c.Long >= -180 && c.Long <= 180):
+		return fmt.Errorf("longitude %g is outside [-180, 180]", c.Long)
+	}
+
+	return nil
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// Haversine returns the great-circle distance between a and b on a sphere of radius EarthRadius.
+func Haversine(a, b moretypes.Coord) float64 {
+	phi1, phi2 := radians(a.Lat), radians(b.Lat)
+	dPhi, dLambda := phi2-phi1, radians(b.Long-a.Long)
+
+	h := math.Pow(math.Sin(dPhi/2), 2) + math.Cos(phi1)*math.Cos(phi2)*math.Pow(math.Sin(dLambda/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+// Vincenty returns the distance between a and b along the WGS-84 ellipsoid, using Vincenty's inverse
+// formula. It returns ErrNoConvergence if the formula doesn't converge within 200 iterations.
+func Vincenty(a, b moretypes.Coord) (float64, error) {
+	const f = wgs84F
+
+	L := radians(b.Long - a.Long)
+	U1 := math.Atan((1 - f) * math.Tan(radians(a.Lat)))
+	U2 := math.Atan((1 - f) * math.Tan(radians(b.Lat)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	for i := 0; ; i++ {
+		if i == 200 {
+			return 0, ErrNoConvergence
+		}
+
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// the points coincide.
+			return 0, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			// both points on the equator leave cos2SigmaM at 0.
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			break
+		}
+	}
+
+	u2 := cosSqAlpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return wgs84B * A * (sigma - deltaSigma), nil
+}
+
+// Bearing returns the initial great-circle bearing from a to b, in degrees clockwise from north in
+// [0, 360). The bearing between identical points is 0.
+func Bearing(a, b moretypes.Coord) float64 {
+	phi1, phi2 := radians(a.Lat), radians(b.Lat)
+	dLambda := radians(b.Long - a.Long)
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// Neighbor is a named place and its distance from a query point.
+type Neighbor struct {
+	Name     string
+	Coord    moretypes.Coord
+	Distance float64 // metres, by Haversine
+}
+
+// Nearest returns the k places in places closest to from, nearest first, by Haversine distance. Places the
+// same distance away are ordered by name. If k is negative or larger than len(places), every place is
+// returned. Nearest looks at every place; for large catalogs, build an Index.
+func Nearest(places map[string]moretypes.Coord, from moretypes.Coord, k int) []Neighbor {
+	all := make([]Neighbor, 0, len(places))
+	for name, c := range places {
+		all = append(all, Neighbor{Name: name, Coord: c, Distance: Haversine(from, c)})
+	}
+	sortNeighbors(all)
+
+	if k >= 0 && k < len(all) {
+		all = all[:k]
+	}
+
+	return all
+}
+
+// Within returns the places within radius metres of center, nearest first, by Haversine distance. Like
+// Nearest, it looks at every place.
+func Within(places map[string]moretypes.Coord, center moretypes.Coord, radius float64) []Neighbor {
+	var found []Neighbor
+	for name, c := range places {
+		if d := Haversine(center, c); d <= radius {
+			found = append(found, Neighbor{Name: name, Coord: c, Distance: d})
+		}
+	}
+	sortNeighbors(found)
+
+	return found
+}
+
+// sortNeighbors sorts by distance, then by name.
+func sortNeighbors(list []Neighbor) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Distance != list[j].Distance {
+			return list[i].Distance < list[j].Distance
+		}
+		return list[i].Name < list[j].Name
+	})
+}
+
+// BBox is a latitude/longitude bounding box, in degrees. A box whose West edge is east of its East edge
+// crosses the antimeridian: {South: -20, West: 170, North: -10, East: -170} covers Fiji.
+type BBox struct {
+	South, West, North, East float64
+}
+
+// Contains reports whether c is inside b, edges included.
+func (b BBox) Contains(c moretypes.Coord) bool {
+	if c.Lat < b.South || c.Lat > b.North {
+		return false
+	}
+	if b.West <= b.East {
+		return c.Long >= b.West && c.Long <= b.East
+	}
+
+	return c.Long >= b.West || c.Long <= b.East
+}
+
+// InBox returns the places inside b. Like Nearest, it looks at every place.
+func InBox(places map[string]moretypes.Coord, b BBox) map[string]moretypes.Coord {
+	found := map[string]moretypes.Coord{}
+	for name, c := range places {
+		if b.Contains(c) {
+			found[name] = c
+		}
+	}
+
+	return found
+}
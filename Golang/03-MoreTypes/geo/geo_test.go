@@ -0,0 +1,150 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// within reports whether got is within tol of want.
+func within(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol
+}
+
+func TestHaversine(t *testing.T) {
+	quarter := math.Pi * EarthRadius / 2
+
+	tests := []struct {
+		name string
+		a, b moretypes.Coord
+		want float64
+		tol  float64
+	}{
+		{"same point", Cities["Chicago"], Cities["Chicago"], 0, 0},
+		{"quarter of the equator", moretypes.Coord{}, moretypes.Coord{Long: 90}, quarter, 1e-6},
+		{"equator to pole", moretypes.Coord{}, moretypes.Coord{Lat: 90}, quarter, 1e-6},
+		{"antipodes", moretypes.Coord{Lat: 10, Long: 20}, moretypes.Coord{Lat: -10, Long: -160}, 2 * quarter, 1e-6},
+		{"across the date line", moretypes.Coord{Long: 179.5}, moretypes.Coord{Long: -179.5}, quarter / 90, 1e-6},
+		// about 3,936 km on a sphere.
+		{"New York to Los Angeles", Cities["New York City"], Cities["Los Angeles"], 3935.7e3, 0.5e3},
+	}
+
+	for _, tt := range tests {
+		if got := Haversine(tt.a, tt.b); !within(got, tt.want, tt.tol) {
+			t.Errorf("%s: Haversine = %.3f, want %.3f ± %g", tt.name, got, tt.want, tt.tol)
+		}
+		if got, back := Haversine(tt.a, tt.b), Haversine(tt.b, tt.a); got != back {
+			t.Errorf("%s: Haversine isn't symmetric: %g and %g", tt.name, got, back)
+		}
+	}
+}
+
+func TestVincenty(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b moretypes.Coord
+		want float64
+		tol  float64
+	}{
+		{"same point", Cities["Houston"], Cities["Houston"], 0, 0},
+		// Vincenty's own test line, Flinders Peak to Buninyong, as checked by GeographicLib.
+		{"Flinders Peak to Buninyong",
+			moretypes.Coord{Lat: -(37 + 57/60.0 + 3.72030/3600), Long: 144 + 25/60.0 + 29.52440/3600},
+			moretypes.Coord{Lat: -(37 + 39/60.0 + 10.15610/3600), Long: 143 + 55/60.0 + 35.38390/3600},
+			54972.271, 1e-3},
+		// the equator is the ellipsoid's semi-major circle.
+		{"along the equator", moretypes.Coord{}, moretypes.Coord{Long: 90}, math.Pi * wgs84A / 2, 1e-3},
+		{"meridian quadrant", moretypes.Coord{}, moretypes.Coord{Lat: 90}, 10001965.729, 1e-3},
+		{"New York to Los Angeles", Cities["New York City"], Cities["Los Angeles"], 3944.4e3, 1e3},
+	}
+
+	for _, tt := range tests {
+		got, err := Vincenty(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !within(got, tt.want, tt.tol) {
+			t.Errorf("%s: Vincenty = %.4f, want %.4f ± %g", tt.name, got, tt.want, tt.tol)
+		}
+	}
+}
+
+func TestVincentyNearlyAntipodal(t *testing.T) {
+	if _, err := Vincenty(moretypes.Coord{}, moretypes.Coord{Lat: 0.5, Long: 179.7}); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Vincenty on nearly antipodal points = %v, want ErrNoConvergence", err)
+	}
+}
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b moretypes.Coord
+		want float64
+	}{
+		{"north", moretypes.Coord{}, moretypes.Coord{Lat: 10}, 0},
+		{"east", moretypes.Coord{}, moretypes.Coord{Long: 10}, 90},
+		{"south", moretypes.Coord{Lat: 10}, moretypes.Coord{}, 180},
+		{"west", moretypes.Coord{}, moretypes.Coord{Long: -10}, 270},
+		{"east across the date line", moretypes.Coord{Long: 179}, moretypes.Coord{Long: -179}, 90},
+		{"same point", Cities["Pittsburgh"], Cities["Pittsburgh"], 0},
+		// the great circle from New York to Los Angeles sets out west-northwest.
+		{"New York to Los Angeles", Cities["New York City"], Cities["Los Angeles"], 273.7},
+	}
+
+	for _, tt := range tests {
+		if got := Bearing(tt.a, tt.b); !within(got, tt.want, 0.1) {
+			t.Errorf("%s: Bearing = %.3f, want %.1f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	// Trenton, NJ sits between New York and Philadelphia, a little nearer Philadelphia.
+	trenton := moretypes.Coord{Lat: 40.22058, Long: -74.75972}
+
+	got := Nearest(Cities, trenton, 3)
+	want := []string{"Philadelphia", "New York City", "Washington D.C."}
+	if len(got) != len(want) {
+		t.Fatalf("Nearest returned %d places, want %d", len(got), len(want))
+	}
+	for i, n := range got {
+		if n.Name != want[i] {
+			t.Errorf("Nearest[%d] = %s, want %s", i, n.Name, want[i])
+		}
+		if n.Coord != Cities[n.Name] || n.Distance != Haversine(trenton, n.Coord) {
+			t.Errorf("Nearest[%d] = %+v doesn't match Cities and Haversine", i, n)
+		}
+	}
+
+	if got := Nearest(Cities, trenton, -1); len(got) != len(Cities) {
+		t.Errorf("Nearest with k = -1 returned %d places, want all %d", len(got), len(Cities))
+	}
+	if got := Nearest(Cities, trenton, 100); len(got) != len(Cities) {
+		t.Errorf("Nearest with k = 100 returned %d places, want all %d", len(got), len(Cities))
+	}
+	if got := Nearest(nil, trenton, 3); len(got) != 0 {
+		t.Errorf("Nearest on no places = %v, want none", got)
+	}
+
+	// ties are broken by name.
+	ties := map[string]moretypes.Coord{"b": {Long: 1}, "a": {Long: -1}, "c": {Lat: 5}}
+	if got := Nearest(ties, moretypes.Coord{}, 2); got[0].Name != "a" || got[1].Name != "b" {
+		t.Errorf("Nearest with ties = %v, want a then b", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []moretypes.Coord{{}, {Lat: 90, Long: 180}, {Lat: -90, Long: -180}, Cities["Chicago"]} {
+		if err := Validate(c); err != nil {
+			t.Errorf("Validate(%v) = %v", c, err)
+		}
+	}
+	for _, c := range []moretypes.Coord{{Lat: 91}, {Long: -181}, {Lat: math.NaN()}, {Long: math.Inf(1)}} {
+		if err := Validate(c); err == nil {
+			t.Errorf("Validate(%v) succeeded", c)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package geo
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// Index is a spatial index over named places: a k-d tree of the places' positions as points on the unit
+// sphere. Straight-line (chord) distance between those points grows with great-circle distance, so
+// nearest-neighbour and radius searches prune the tree exactly, with no special cases at the poles or the
+// antimeridian. Each subtree also records the range of latitudes and longitudes below it for BBox searches.
+//
+// Queries take O(log n) time on average, against O(n) for Nearest, Within and InBox. Deleted places are
+// only marked, and the tree is rebuilt once they outnumber the rest. An Index is not safe for concurrent
+// use.
+type Index struct {
+	root  *node
+	nodes map[string]*node // live places by name
+	dead  int              // deleted nodes still in the tree
+}
+
+// node is one place in the k-d tree.
+type node struct {
+	name        string
+	coord       moretypes.Coord
+	p           [3]float64 // unit-sphere position
+	axis        int        // coordinate of p that splits the children
+	left, right *node      // p[axis] less than this node's, and greater or equal
+	deleted     bool
+
+	// bounds of every coordinate in this subtree, deleted ones included.
+	south, north, west, east float64
+}
+
+func newNode(name string, c moretypes.Coord) *node {
+	return &node{name: name, coord: c, p: unitVector(c), south: c.Lat, north: c.Lat, west: c.Long, east: c.Long}
+}
+
+// grow widens n's bounds to include c.
+func (n *node) grow(c moretypes.Coord) {
+	n.south, n.north = min(n.south, c.Lat), max(n.north, c.Lat)
+	n.west, n.east = min(n.west, c.Long), max(n.east, c.Long)
+}
+
+// unitVector returns c's position on the unit sphere.
+func unitVector(c moretypes.Coord) [3]float64 {
+	sinLat, cosLat := math.Sincos(radians(c.Lat))
+	sinLong, cosLong := math.Sincos(radians(c.Long))
+	return [3]float64{cosLat * cosLong, cosLat * sinLong, sinLat}
+}
+
+// chord returns the squared straight-line distance between two points on the unit sphere.
+func chord(a, b [3]float64) float64 {
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+// chordFor returns the squared chord length of a great-circle distance of d metres.
+func chordFor(d float64) float64 {
+	c := 2 * math.Sin(min(d/EarthRadius, math.Pi)/2)
+	return c * c
+}
+
+// NewIndex returns a balanced index of places. Places with invalid coordinates are left out.
+func NewIndex(places map[string]moretypes.Coord) *Index {
+	nodes := make([]*node, 0, len(places))
+	for name, c := range places {
+		if validate(c) == nil {
+			nodes = append(nodes, newNode(name, c))
+		}
+	}
+
+	x := &Index{nodes: make(map[string]*node, len(nodes))}
+	for _, n := range nodes {
+		x.nodes[n.name] = n
+	}
+	x.root = build(nodes, 0)
+
+	return x
+}
+
+// build returns a balanced tree of nodes, splitting on the median along axis, then the next axis, and so on.
+func build(nodes []*node, axis int) *node {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].p[axis] < nodes[j].p[axis] })
+	mid := len(nodes) / 2
+	// everything equal to the median goes right, so the median is the first of its value.
+	for mid > 0 && nodes[mid-1].p[axis] == nodes[mid].p[axis] {
+		mid--
+	}
+
+	n := nodes[mid]
+	n.axis, n.deleted = axis, false
+	n.left = build(nodes[:mid], (axis+1)%3)
+	n.right = build(nodes[mid+1:], (axis+1)%3)
+	n.south, n.north, n.west, n.east = n.coord.Lat, n.coord.Lat, n.coord.Long, n.coord.Long
+	for _, child := range []*node{n.left, n.right} {
+		if child != nil {
+			n.south, n.north = min(n.south, child.south), max(n.north, child.north)
+			n.west, n.east = min(n.west, child.west), max(n.east, child.east)
+		}
+	}
+
+	return n
+}
+
+// Len returns the number of places in the index.
+func (x *Index) Len() int {
+	return len(x.nodes)
+}
+
+// Insert adds a place to the index, replacing any place with the same name. It returns an error if c is
+// not a valid coordinate.
+func (x *Index) Insert(name string, c moretypes.Coord) error {
+	if err := Validate(c); err != nil {
+		return err
+	}
+	if x.nodes == nil {
+		x.nodes = map[string]*node{}
+	}
+	x.Delete(name)
+
+	n := newNode(name, c)
+	x.nodes[name] = n
+	if x.root == nil {
+		x.root = n
+		return nil
+	}
+
+	for parent := x.root; ; {
+		parent.grow(c)
+		child := &parent.right
+		if n.p[parent.axis] < parent.p[parent.axis] {
+			child = &parent.left
+		}
+		if *child == nil {
+			n.axis = (parent.axis + 1) % 3
+			*child = n
+			return nil
+		}
+		parent = *child
+	}
+}
+
+// Delete removes the place called name from the index and reports whether it was there.
+func (x *Index) Delete(name string) bool {
+	n, ok := x.nodes[name]
+	if !ok {
+		return false
+	}
+
+	n.deleted = true
+	delete(x.nodes, name)
+	x.dead++
+	if x.dead > len(x.nodes) {
+		x.rebuild()
+	}
+
+	return true
+}
+
+// rebuild rebuilds a balanced tree from the live places, dropping the deleted ones.
+func (x *Index) rebuild() {
+	nodes := make([]*node, 0, len(x.nodes))
+	for _, n := range x.nodes {
+		nodes = append(nodes, n)
+	}
+	x.root, x.dead = build(nodes, 0), 0
+}
+
+// Nearest returns the k places closest to from, nearest first, as the package's Nearest function does.
+func (x *Index) Nearest(from moretypes.Coord, k int) []Neighbor {
+	if k < 0 || k > len(x.nodes) {
+		k = len(x.nodes)
+	}
+	if k == 0 {
+		return nil
+	}
+
+	q := unitVector(from)
+	best := &farthestFirst{}
+	var search func(n *node)
+	search = func(n *node) {
+		if n == nil {
+			return
+		}
+
+		if !n.deleted {
+			if d := chord(q, n.p); best.Len() < k {
+				heap.Push(best, candidate{n, d})
+			} else if d < (*best)[0].dist || d == (*best)[0].dist && n.name < (*best)[0].name {
+				(*best)[0] = candidate{n, d}
+				heap.Fix(best, 0)
+			}
+		}
+
+		near, far := n.left, n.right
+		diff := q[n.axis] - n.p[n.axis]
+		if diff >= 0 {
+			near, far = far, near
+		}
+		search(near)
+		if best.Len() < k || diff*diff <= (*best)[0].dist {
+			search(far)
+		}
+	}
+	search(x.root)
+
+	found := make([]Neighbor, best.Len())
+	for i, c := range *best {
+		found[i] = Neighbor{Name: c.name, Coord: c.coord, Distance: Haversine(from, c.coord)}
+	}
+	sortNeighbors(found)
+
+	return found
+}
+
+// Within returns the places within radius metres of center, nearest first, as the package's Within
+// function does.
+func (x *Index) Within(center moretypes.Coord, radius float64) []Neighbor {
+	if radius < 0 {
+		return nil
+	}
+
+	q := unitVector(center)
+	// widen the chord limit a little so rounding can't exclude a place Haversine puts on the boundary.
+	limit := chordFor(radius) * (1 + 1e-9)
+
+	var found []Neighbor
+	var search func(n *node)
+	search = func(n *node) {
+		if n == nil {
+			return
+		}
+
+		if !n.deleted && chord(q, n.p) <= limit {
+			if d := Haversine(center, n.coord); d <= radius {
+				found = append(found, Neighbor{Name: n.name, Coord: n.coord, Distance: d})
+			}
+		}
+
+		diff := q[n.axis] - n.p[n.axis]
+		if diff < 0 || diff*diff <= limit {
+			search(n.left)
+		}
+		if diff >= 0 || diff*diff <= limit {
+			search(n.right)
+		}
+	}
+	search(x.root)
+	sortNeighbors(found)
+
+	return found
+}
+
+// InBox returns the places inside b, as the package's InBox function does.
+func (x *Index) InBox(b BBox) map[string]moretypes.Coord {
+	found := map[string]moretypes.Coord{}
+
+	var search func(n *node)
+	search = func(n *node) {
+		if n == nil || n.north < b.South || n.south > b.North || !b.overlapsLongitudes(n.west, n.east) {
+			return
+		}
+
+		if !n.deleted && b.Contains(n.coord) {
+			found[n.name] = n.coord
+		}
+		search(n.left)
+		search(n.right)
+	}
+	search(x.root)
+
+	return found
+}
+
+// overlapsLongitudes reports whether the longitudes [west, east] overlap b's.
+func (b BBox) overlapsLongitudes(west, east float64) bool {
+	if b.West <= b.East {
+		return east >= b.West && west <= b.East
+	}
+
+	// b crosses the antimeridian, so it covers [b.West, 180] and [-180, b.East].
+	return east >= b.West || west <= b.East
+}
+
+// candidate is a place found by Index.Nearest and its squared chord distance from the query.
+type candidate struct {
+	*node
+	dist float64
+}
+
+// farthestFirst is a max-heap of candidates, so the worst of the k best is on top; ties put the later
+// name on top.
+type farthestFirst []candidate
+
+func (h farthestFirst) Len() int { return len(h) }
+func (h farthestFirst) Less(i, j int) bool {
+	if h[i].dist != h[j].dist {
+		return h[i].dist > h[j].dist
+	}
+	return h[i].name > h[j].name
+}
+func (h farthestFirst) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *farthestFirst) Push(x any)   { *h = append(*h, x.(candidate)) }
+func (h *farthestFirst) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
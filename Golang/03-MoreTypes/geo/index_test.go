@@ -0,0 +1,212 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// randomPlaces returns n places spread uniformly over the sphere, named p0, p1, ...
+func randomPlaces(r *rand.Rand, n int) map[string]moretypes.Coord {
+	places := make(map[string]moretypes.Coord, n)
+	for i := 0; i < n; i++ {
+		places[fmt.Sprintf("p%d", i)] = randomCoord(r)
+	}
+
+	return places
+}
+
+func randomCoord(r *rand.Rand) moretypes.Coord {
+	// uniform on the sphere, not in latitude, so the poles aren't crowded.
+	lat := degrees(math.Asin(2*r.Float64() - 1))
+	return moretypes.Coord{Lat: lat, Long: 360*r.Float64() - 180}
+}
+
+// neighborNames returns the names in list, in order.
+func neighborNames(list []Neighbor) []string {
+	var out []string
+	for _, n := range list {
+		out = append(out, n.Name)
+	}
+
+	return out
+}
+
+// checkIndex compares every kind of query on x with the naive scans over places.
+func checkIndex(t *testing.T, r *rand.Rand, x *Index, places map[string]moretypes.Coord) {
+	t.Helper()
+
+	if x.Len() != len(places) {
+		t.Fatalf("Len() = %d, want %d", x.Len(), len(places))
+	}
+
+	for i := 0; i < 50; i++ {
+		from := randomCoord(r)
+
+		for _, k := range []int{0, 1, 5, -1} {
+			got, want := neighborNames(x.Nearest(from, k)), neighborNames(Nearest(places, from, k))
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Nearest(%v, %d) = %v, want %v", from, k, got, want)
+			}
+		}
+
+		for _, radius := range []float64{0, 500e3, 3000e3, 25000e3} {
+			got, want := neighborNames(x.Within(from, radius)), neighborNames(Within(places, from, radius))
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Within(%v, %g) = %v, want %v", from, radius, got, want)
+			}
+		}
+
+		south := from.Lat - 30*r.Float64()
+		b := BBox{South: south, North: south + 40*r.Float64(), West: from.Long, East: from.Long + 90*r.Float64()}
+		if b.East > 180 {
+			b.East -= 360 // crosses the antimeridian
+		}
+		if got, want := x.InBox(b), InBox(places, b); !reflect.DeepEqual(got, want) {
+			t.Fatalf("InBox(%+v) found %d places, want %d", b, len(got), len(want))
+		}
+	}
+}
+
+func TestIndexMatchesScan(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	places := randomPlaces(r, 2000)
+
+	x := NewIndex(places)
+	checkIndex(t, r, x, places)
+
+	// delete half, insert some new places and move some old ones.
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("p%d", i)
+		if !x.Delete(name) {
+			t.Fatalf("Delete(%s) = false", name)
+		}
+		delete(places, name)
+	}
+	for i := 1500; i < 2500; i++ {
+		name, c := fmt.Sprintf("p%d", i), randomCoord(r)
+		if err := x.Insert(name, c); err != nil {
+			t.Fatal(err)
+		}
+		places[name] = c
+	}
+	checkIndex(t, r, x, places)
+
+	if x.Delete("p0") {
+		t.Error("Delete of a deleted place = true")
+	}
+}
+
+func TestIndexFromEmpty(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	var x Index
+	if got := x.Nearest(moretypes.Coord{}, 3); len(got) != 0 {
+		t.Errorf("Nearest on an empty index = %v", got)
+	}
+
+	places := map[string]moretypes.Coord{}
+	for name, c := range Cities {
+		if err := x.Insert(name, c); err != nil {
+			t.Fatal(err)
+		}
+		places[name] = c
+	}
+	checkIndex(t, r, &x, places)
+
+	if err := x.Insert("nowhere", moretypes.Coord{Lat: 100}); err == nil {
+		t.Error("Insert of an invalid coordinate succeeded")
+	}
+}
+
+func TestIndexEdges(t *testing.T) {
+	places := map[string]moretypes.Coord{
+		"north pole": {Lat: 90},
+		"near pole":  {Lat: 89, Long: 180},
+		"fiji":       {Lat: -17.7, Long: 178.1},
+		"samoa":      {Lat: -13.8, Long: -172.1},
+		"greenwich":  {Lat: 51.48, Long: 0},
+	}
+	x := NewIndex(places)
+
+	// the pole and a place 111 km away across it.
+	if got := neighborNames(x.Within(moretypes.Coord{Lat: 89.5, Long: 0}, 200e3)); !reflect.DeepEqual(got, []string{"north pole", "near pole"}) {
+		t.Errorf("Within near the pole = %v", got)
+	}
+	// Fiji and Samoa are neighbours across the antimeridian.
+	if got := neighborNames(x.Nearest(places["fiji"], 2)); !reflect.DeepEqual(got, []string{"fiji", "samoa"}) {
+		t.Errorf("Nearest to Fiji = %v", got)
+	}
+	if got := x.InBox(BBox{South: -20, West: 170, North: -10, East: -170}); len(got) != 2 {
+		t.Errorf("InBox across the antimeridian = %v, want Fiji and Samoa", got)
+	}
+	// a place replaced by Insert moves.
+	x.Insert("greenwich", moretypes.Coord{Lat: -51.48})
+	if got := x.InBox(BBox{South: 50, West: -1, North: 52, East: 1}); len(got) != 0 {
+		t.Errorf("InBox found %v at Greenwich's old position", got)
+	}
+	if x.Len() != len(places) {
+		t.Errorf("Len() = %d after replacing a place, want %d", x.Len(), len(places))
+	}
+}
+
+// benchmark sizes: the lesson's map literal is 8 places; these are the catalogs a scan can't keep up with.
+var benchSizes = []int{1_000, 100_000}
+
+func benchQueries(b *testing.B, scan func(places map[string]moretypes.Coord, c moretypes.Coord), indexed func(x *Index, c moretypes.Coord)) {
+	for _, n := range benchSizes {
+		r := rand.New(rand.NewPCG(5, 6))
+		places := randomPlaces(r, n)
+		x := NewIndex(places)
+		queries := make([]moretypes.Coord, 256)
+		for i := range queries {
+			queries[i] = randomCoord(r)
+		}
+
+		b.Run(fmt.Sprintf("scan/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scan(places, queries[i%len(queries)])
+			}
+		})
+		b.Run(fmt.Sprintf("index/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				indexed(x, queries[i%len(queries)])
+			}
+		})
+	}
+}
+
+func BenchmarkNearest(b *testing.B) {
+	benchQueries(b,
+		func(places map[string]moretypes.Coord, c moretypes.Coord) { Nearest(places, c, 5) },
+		func(x *Index, c moretypes.Coord) { x.Nearest(c, 5) })
+}
+
+func BenchmarkWithin(b *testing.B) {
+	benchQueries(b,
+		func(places map[string]moretypes.Coord, c moretypes.Coord) { Within(places, c, 500e3) },
+		func(x *Index, c moretypes.Coord) { x.Within(c, 500e3) })
+}
+
+func BenchmarkInBox(b *testing.B) {
+	box := func(c moretypes.Coord) BBox {
+		return BBox{South: c.Lat - 5, West: c.Long, North: c.Lat + 5, East: min(c.Long+10, 180)}
+	}
+	benchQueries(b,
+		func(places map[string]moretypes.Coord, c moretypes.Coord) { InBox(places, box(c)) },
+		func(x *Index, c moretypes.Coord) { x.InBox(box(c)) })
+}
+
+func BenchmarkIndexInsertDelete(b *testing.B) {
+	r := rand.New(rand.NewPCG(7, 8))
+	x := NewIndex(randomPlaces(r, 100_000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		name := fmt.Sprintf("p%d", i%100_000)
+		x.Delete(name)
+		x.Insert(name, randomCoord(r))
+	}
+}
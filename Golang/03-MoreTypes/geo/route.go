@@ -0,0 +1,224 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// MaxExact is the most places ExactRoute will accept; it tries (n-1)! orderings.
+const MaxExact = 10
+
+// ErrNoPlaces is returned when asked to plan a route through no places.
+var ErrNoPlaces = errors.New("geo: no places to visit")
+
+// Route is a round trip that visits every place once and returns to the first.
+type Route struct {
+	Stops    []string // names in visiting order, starting with the start; the return leg is implied
+	Distance float64  // metres, by Haversine, including the return leg
+}
+
+// Leg is one step of a Route.
+type Leg struct {
+	From, To string
+	Distance float64 // metres, by Haversine
+}
+
+// Legs returns the steps of r with their distances, including the return to the start, looking the stops up
+// in places.
+func (r Route) Legs(places map[string]moretypes.Coord) []Leg {
+	if len(r.Stops) < 2 {
+		return nil
+	}
+
+	legs := make([]Leg, len(r.Stops))
+	for i, from := range r.Stops {
+		to := r.Stops[(i+1)%len(r.Stops)]
+		legs[i] = Leg{From: from, To: to, Distance: Haversine(places[from], places[to])}
+	}
+
+	return legs
+}
+
+// planner holds the distances between a set of places, by index.
+type planner struct {
+	names []string    // sorted, so routes don't depend on map order
+	dist  [][]float64 // Haversine distances
+}
+
+// newPlanner sets up a planner for places, with start (or the first name, if start is empty) as place 0.
+func newPlanner(places map[string]moretypes.Coord, start string) (*planner, error) {
+	if len(places) == 0 {
+		return nil, ErrNoPlaces
+	}
+	names := names(places)
+	if start == "" {
+		start = names[0]
+	}
+	if _, ok := places[start]; !ok {
+		return nil, fmt.Errorf("geo: start %q is not one of the places", start)
+	}
+
+	// move start to the front, keeping the rest sorted.
+	ordered := []string{start}
+	for _, name := range names {
+		if name != start {
+			ordered = append(ordered, name)
+		}
+	}
+
+	dist := make([][]float64, len(ordered))
+	for i := range ordered {
+		dist[i] = make([]float64, len(ordered))
+	}
+	// fill both halves from one computation, so rounding can't make a route and its reverse differ.
+	for i, a := range ordered {
+		for j := i + 1; j < len(ordered); j++ {
+			d := Haversine(places[a], places[ordered[j]])
+			dist[i][j], dist[j][i] = d, d
+		}
+	}
+
+	return &planner{names: ordered, dist: dist}, nil
+}
+
+// length returns the length of the round trip through the places in order.
+func (p *planner) length(order []int) float64 {
+	total := 0.0
+	for i, a := range order {
+		total += p.dist[a][order[(i+1)%len(order)]]
+	}
+
+	return total
+}
+
+// route turns an order of place indexes into a Route.
+func (p *planner) route(order []int) Route {
+	stops := make([]string, len(order))
+	for i, a := range order {
+		stops[i] = p.names[a]
+	}
+
+	return Route{Stops: stops, Distance: p.length(order)}
+}
+
+// nearestNeighbor builds a route from place 0 by always going to the closest place not yet visited.
+func (p *planner) nearestNeighbor() []int {
+	n := len(p.names)
+	visited := make([]bool, n)
+	order := []int{0}
+	visited[0] = true
+
+	for len(order) < n {
+		from, next := order[len(order)-1], -1
+		for to := 0; to < n; to++ {
+			if !visited[to] && (next < 0 || p.dist[from][to] < p.dist[from][next]) {
+				next = to
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+	}
+
+	return order
+}
+
+// twoOpt improves order in place: while reversing some stretch of the route makes it shorter, it does so.
+// The first stop stays first.
+func (p *planner) twoOpt(order []int) {
+	n := len(order)
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			// reversing everything after the start (i = 1, j = n-1) only reverses the route.
+			for j := i + 1; j < n && !(i == 1 && j == n-1); j++ {
+				// replace the legs a-b and c-d with a-c and b-d by reversing b..c.
+				a, b, c, d := order[i-1], order[i], order[j], order[(j+1)%n]
+				delta := p.dist[a][c] + p.dist[b][d] - p.dist[a][b] - p.dist[c][d]
+				// ignore improvements under a millimetre, which may be rounding.
+				if delta < -1e-3 {
+					for l, r := i, j; l < r; l, r = l+1, r-1 {
+						order[l], order[r] = order[r], order[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+}
+
+// NearestNeighborRoute returns a round trip through places from start that always goes to the closest place
+// not yet visited. If start is empty, the route starts at the first place by name. It is fast but often
+// 20-25% longer than the best route.
+func NearestNeighborRoute(places map[string]moretypes.Coord, start string) (Route, error) {
+	p, err := newPlanner(places, start)
+	if err != nil {
+		return Route{}, err
+	}
+
+	return p.route(p.nearestNeighbor()), nil
+}
+
+// PlanRoute returns a short round trip through places from start: the nearest-neighbour route, improved
+// with 2-opt moves until no reversal of a stretch of the route shortens it. If start is empty, the route
+// starts at the first place by name. The result is usually within a few percent of the best route, but
+// isn't guaranteed to be the best; see ExactRoute.
+func PlanRoute(places map[string]moretypes.Coord, start string) (Route, error) {
+	p, err := newPlanner(places, start)
+	if err != nil {
+		return Route{}, err
+	}
+
+	order := p.nearestNeighbor()
+	p.twoOpt(order)
+	return p.route(order), nil
+}
+
+// ExactRoute returns the shortest round trip through places from start by trying every ordering, so it only
+// accepts up to MaxExact places. If start is empty, the route starts at the first place by name. Of routes
+// the same length, such as a route and its reverse, it returns the one whose stops come first by name.
+func ExactRoute(places map[string]moretypes.Coord, start string) (Route, error) {
+	if len(places) > MaxExact {
+		return Route{}, fmt.Errorf("geo: ExactRoute accepts at most %d places, got %d", MaxExact, len(places))
+	}
+	p, err := newPlanner(places, start)
+	if err != nil {
+		return Route{}, err
+	}
+
+	n := len(p.names)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	best, bestLen := append([]int(nil), order...), math.Inf(1)
+
+	// permute order[1:] in lexicographic order, pruning partial routes already longer than the best.
+	used := make([]bool, n)
+	used[0] = true
+	var search func(depth int, sofar float64)
+	search = func(depth int, sofar float64) {
+		if sofar >= bestLen {
+			return
+		}
+		if depth == n {
+			if total := sofar + p.dist[order[n-1]][0]; total < bestLen {
+				bestLen = total
+				copy(best, order)
+			}
+			return
+		}
+		for next := 1; next < n; next++ {
+			if !used[next] {
+				used[next], order[depth] = true, next
+				search(depth+1, sofar+p.dist[order[depth-1]][next])
+				used[next] = false
+			}
+		}
+	}
+	search(1, 0)
+
+	return p.route(best), nil
+}
@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// checkRoute checks that r visits every place once from start and that its distance adds up.
+func checkRoute(t *testing.T, name string, r Route, places map[string]moretypes.Coord, start string) {
+	t.Helper()
+
+	if len(r.Stops) != len(places) || r.Stops[0] != start {
+		t.Fatalf("%s: stops %v don't start at %s and visit all %d places", name, r.Stops, start, len(places))
+	}
+	seen := map[string]bool{}
+	for _, s := range r.Stops {
+		if _, ok := places[s]; !ok || seen[s] {
+			t.Fatalf("%s: stops %v visit %q twice or it isn't a place", name, r.Stops, s)
+		}
+		seen[s] = true
+	}
+
+	total := 0.0
+	for _, leg := range r.Legs(places) {
+		total += leg.Distance
+	}
+	if math.Abs(total-r.Distance) > 1e-6 {
+		t.Errorf("%s: legs add up to %g, Distance is %g", name, total, r.Distance)
+	}
+}
+
+func TestRoutesAgainstExact(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	for trial := 0; trial < 30; trial++ {
+		n := 1 + trial%MaxExact
+		places := randomPlaces(r, n)
+		start := "p0"
+
+		exact, err := ExactRoute(places, start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nn, err := NearestNeighborRoute(places, start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		planned, err := PlanRoute(places, start)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkRoute(t, "exact", exact, places, start)
+		checkRoute(t, "nearest neighbour", nn, places, start)
+		checkRoute(t, "planned", planned, places, start)
+
+		const eps = 1e-6
+		if exact.Distance > planned.Distance+eps || planned.Distance > nn.Distance+eps {
+			t.Errorf("n=%d: want exact %.0f <= planned %.0f <= nearest neighbour %.0f", n, exact.Distance, planned.Distance, nn.Distance)
+		}
+		// 2-opt routes on random points are rarely more than a few percent off the best.
+		if planned.Distance > 1.1*exact.Distance {
+			t.Errorf("n=%d: planned %.0f is more than 10%% longer than exact %.0f", n, planned.Distance, exact.Distance)
+		}
+	}
+}
+
+func TestPlanRouteCities(t *testing.T) {
+	exact, err := ExactRoute(Cities, "Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	planned, err := PlanRoute(Cities, "Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the best tour of the lesson's cities: the east coast, then west, then back through Houston.
+	want := []string{"Chicago", "Pittsburgh", "New York City", "Philadelphia", "Washington D.C.", "Houston", "Los Angeles", "San Francisco"}
+	if !reflect.DeepEqual(exact.Stops, want) {
+		t.Errorf("ExactRoute = %v, want %v", exact.Stops, want)
+	}
+	if math.Abs(planned.Distance-exact.Distance) > 1e-6 {
+		t.Errorf("PlanRoute = %v (%.0f m), want the exact route's %.0f m", planned.Stops, planned.Distance, exact.Distance)
+	}
+}
+
+func TestRouteErrors(t *testing.T) {
+	if _, err := PlanRoute(nil, ""); !errors.Is(err, ErrNoPlaces) {
+		t.Errorf("PlanRoute with no places = %v, want ErrNoPlaces", err)
+	}
+	if _, err := PlanRoute(Cities, "Boston"); err == nil {
+		t.Error("PlanRoute from a start that isn't a place succeeded")
+	}
+	if _, err := ExactRoute(randomPlaces(rand.New(rand.NewPCG(1, 1)), MaxExact+1), ""); err == nil {
+		t.Errorf("ExactRoute with %d places succeeded", MaxExact+1)
+	}
+
+	// with no start, routes begin at the first place by name.
+	r, err := PlanRoute(Cities, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Stops[0] != "Chicago" {
+		t.Errorf("PlanRoute starts at %s, want Chicago", r.Stops[0])
+	}
+
+	one := map[string]moretypes.Coord{"home": {}}
+	if r, err := PlanRoute(one, ""); err != nil || r.Distance != 0 || len(r.Legs(one)) != 0 {
+		t.Errorf("PlanRoute with one place = %+v, %v", r, err)
+	}
+}
+
+func BenchmarkPlanRoute(b *testing.B) {
+	places := randomPlaces(rand.New(rand.NewPCG(11, 12)), 200)
+	for i := 0; i < b.N; i++ {
+		PlanRoute(places, "p0")
+	}
+}
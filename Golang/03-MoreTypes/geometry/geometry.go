@@ -0,0 +1,213 @@
+// Package geometry works with shapes made of moretypes.Vertex points: polygons, with their area, perimeter,
+// centroid and point-in-polygon tests; convex hulls of point sets; and line segments and where they cross.
+//
+// Vertex coordinates are integers, so the tests that decide orientation, containment and intersection
+// are exact; only lengths, centroids and intersection points need floating point.
+package geometry
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// ErrDegenerate is returned for a polygon with no area, which has no centroid.
+var ErrDegenerate = errors.New("geometry: polygon has no area")
+
+// Polygon is a closed shape through its vertices in order; the last vertex connects back to the first.
+// The vertices may go clockwise or counterclockwise, but the edges shouldn't cross each other.
+type Polygon []moretypes.Vertex
+
+// edges calls fn with each edge of p, from p[i] to the next vertex.
+func (p Polygon) edges(fn func(a, b moretypes.Vertex)) {
+	for i, a := range p {
+		fn(a, p[(i+1)%len(p)])
+	}
+}
+
+// SignedArea2 returns twice the signed area of p by the shoelace formula: positive if the vertices go
+// counterclockwise, negative if clockwise. Doubling keeps it an exact integer.
+func (p Polygon) SignedArea2() int {
+	sum := 0
+	p.edges(func(a, b moretypes.Vertex) { sum += a.Cross(b) })
+
+	return sum
+}
+
+// Area returns the area of p.
+func (p Polygon) Area() float64 {
+	return math.Abs(float64(p.SignedArea2())) / 2
+}
+
+// Perimeter returns the total length of p's edges.
+func (p Polygon) Perimeter() float64 {
+	total := 0.0
+	p.edges(func(a, b moretypes.Vertex) { total += b.Sub(a).Length() })
+
+	return total
+}
+
+// Centroid returns p's center of mass, or ErrDegenerate if p has no area.
+func (p Polygon) Centroid() (moretypes.Vec, error) {
+	a2 := p.SignedArea2()
+	if a2 == 0 {
+		return moretypes.Vec{}, ErrDegenerate
+	}
+
+	// each edge contributes the triangle it makes with the origin, weighted by that triangle's area.
+	var cx, cy int
+	p.edges(func(a, b moretypes.Vertex) {
+		w := a.Cross(b)
+		cx += (a.X + b.X) * w
+		cy += (a.Y + b.Y) * w
+	})
+
+	return moretypes.Vec{X: float64(cx) / float64(3*a2), Y: float64(cy) / float64(3*a2)}, nil
+}
+
+// Contains reports whether pt is inside p or on its boundary.
+func (p Polygon) Contains(pt moretypes.Vertex) bool {
+	inside, boundary := false, false
+	p.edges(func(a, b moretypes.Vertex) {
+		if (Segment{A: a, B: b}).Contains(pt) {
+			boundary = true
+		}
+		// count the edges crossed by a ray from pt towards +X. An edge counts if it spans pt's Y, with a
+		// vertex exactly on the ray belonging to the edge above it, so it's counted once.
+		if (a.Y > pt.Y) != (b.Y > pt.Y) {
+			// the crossing is right of pt if pt is left of the edge going up, or right of it going down.
+			side := b.Sub(a).Cross(pt.Sub(a))
+			if (side > 0) == (b.Y > a.Y) {
+				inside = !inside
+			}
+		}
+	})
+
+	return inside || boundary
+}
+
+// orientation returns the sign of the turn a -> b -> c: 1 counterclockwise, -1 clockwise, 0 collinear.
+func orientation(a, b, c moretypes.Vertex) int {
+	switch cross := b.Sub(a).Cross(c.Sub(a)); {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// ConvexHull returns the smallest convex polygon containing every point, counterclockwise from the lowest
+// leftmost point, without collinear points along its edges. Fewer than three distinct points, or points
+// all on one line, give a hull of just the distinct extreme points.
+func ConvexHull(points []moretypes.Vertex) Polygon {
+	pts := append([]moretypes.Vertex(nil), points...)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X != pts[j].X {
+			return pts[i].X < pts[j].X
+		}
+		return pts[i].Y < pts[j].Y
+	})
+
+	// drop duplicates.
+	uniq := pts[:0]
+	for i, p := range pts {
+		if i == 0 || p != pts[i-1] {
+			uniq = append(uniq, p)
+		}
+	}
+	pts = uniq
+	if len(pts) < 3 {
+		return Polygon(pts)
+	}
+
+	// Andrew's monotone chain: the lower hull left to right, then the upper hull right to left.
+	hull := make(Polygon, 0, 2*len(pts))
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range pts {
+			for len(hull) >= start+2 && orientation(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		// the last point of each chain is the first of the other.
+		hull = hull[:len(hull)-1]
+
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+
+	// start from the lowest, then leftmost, point.
+	low := 0
+	for i, p := range hull {
+		if p.Y < hull[low].Y || p.Y == hull[low].Y && p.X < hull[low].X {
+			low = i
+		}
+	}
+
+	return append(hull[low:], hull[:low]...)
+}
+
+// Segment is the straight line from A to B.
+type Segment struct {
+	A, B moretypes.Vertex
+}
+
+// Length returns the length of s.
+func (s Segment) Length() float64 {
+	return s.B.Sub(s.A).Length()
+}
+
+// Contains reports whether pt lies on s, endpoints included.
+func (s Segment) Contains(pt moretypes.Vertex) bool {
+	return orientation(s.A, s.B, pt) == 0 &&
+		min(s.A.X, s.B.X) <= pt.X && pt.X <= max(s.A.X, s.B.X) &&
+		min(s.A.Y, s.B.Y) <= pt.Y && pt.Y <= max(s.A.Y, s.B.Y)
+}
+
+// Intersects reports whether s and t share at least one point, touching or overlapping included.
+func (s Segment) Intersects(t Segment) bool {
+	o1, o2 := orientation(s.A, s.B, t.A), orientation(s.A, s.B, t.B)
+	o3, o4 := orientation(t.A, t.B, s.A), orientation(t.A, t.B, s.B)
+
+	// a proper crossing: each segment's endpoints are on opposite sides of the other.
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return true
+	}
+
+	// otherwise they meet only if an endpoint of one lies on the other.
+	return s.Contains(t.A) || s.Contains(t.B) || t.Contains(s.A) || t.Contains(s.B)
+}
+
+// Intersection returns the point where s and t meet, and false if they don't. If they overlap along a
+// stretch, it returns the point of the overlap nearest s.A.
+func (s Segment) Intersection(t Segment) (moretypes.Vec, bool) {
+	if !s.Intersects(t) {
+		return moretypes.Vec{}, false
+	}
+
+	d, e := s.B.Sub(s.A), t.B.Sub(t.A)
+	if denom := d.Cross(e); denom != 0 {
+		// s.A + u*d, with u found from the cross products.
+		u := float64(t.A.Sub(s.A).Cross(e)) / float64(denom)
+		return s.A.Vec().Add(d.Vec().Scale(u)), true
+	}
+
+	// parallel, so collinear and overlapping: the nearest to s.A of the endpoints in both segments.
+	var best moretypes.Vertex
+	bestDist := -1
+	for _, pt := range []moretypes.Vertex{s.A, s.B, t.A, t.B} {
+		if s.Contains(pt) && t.Contains(pt) {
+			if dist := pt.Sub(s.A).Dot(pt.Sub(s.A)); bestDist < 0 || dist < bestDist {
+				best, bestDist = pt, dist
+			}
+		}
+	}
+
+	return best.Vec(), true
+}
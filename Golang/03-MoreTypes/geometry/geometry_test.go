@@ -0,0 +1,197 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// poly builds a polygon from x, y pairs.
+func poly(xy ...int) Polygon {
+	p := make(Polygon, len(xy)/2)
+	for i := range p {
+		p[i] = moretypes.Vertex{X: xy[2*i], Y: xy[2*i+1]}
+	}
+
+	return p
+}
+
+func v(x, y int) moretypes.Vertex {
+	return moretypes.Vertex{X: x, Y: y}
+}
+
+var (
+	square   = poly(0, 0, 4, 0, 4, 4, 0, 4)             // counterclockwise
+	squareCW = poly(0, 0, 0, 4, 4, 4, 4, 0)             // clockwise
+	triangle = poly(0, 0, 6, 0, 0, 3)                   // right angle at the origin
+	lShape   = poly(0, 0, 4, 0, 4, 2, 2, 2, 2, 4, 0, 4) // concave
+	line     = poly(0, 0, 2, 2, 4, 4)                   // no area
+)
+
+func TestArea(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         Polygon
+		signed2   int
+		area      float64
+		perimeter float64
+	}{
+		{"square", square, 32, 16, 16},
+		{"clockwise square", squareCW, -32, 16, 16},
+		{"right triangle", triangle, 18, 9, 9 + math.Sqrt(45)},
+		{"L shape", lShape, 24, 12, 16},
+		{"collinear", line, 0, 0, 4 * math.Sqrt2 * 2},
+		{"single point", poly(3, 3), 0, 0, 0},
+		{"empty", nil, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.SignedArea2(); got != tt.signed2 {
+			t.Errorf("%s: SignedArea2 = %d, want %d", tt.name, got, tt.signed2)
+		}
+		if got := tt.p.Area(); got != tt.area {
+			t.Errorf("%s: Area = %g, want %g", tt.name, got, tt.area)
+		}
+		if got := tt.p.Perimeter(); math.Abs(got-tt.perimeter) > 1e-9 {
+			t.Errorf("%s: Perimeter = %g, want %g", tt.name, got, tt.perimeter)
+		}
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Polygon
+		want moretypes.Vec
+	}{
+		{"square", square, moretypes.Vec{X: 2, Y: 2}},
+		{"clockwise square", squareCW, moretypes.Vec{X: 2, Y: 2}},
+		{"triangle", triangle, moretypes.Vec{X: 2, Y: 1}},
+		// two 4x2 rectangles' worth of area, weighted towards the corner they share.
+		{"L shape", lShape, moretypes.Vec{X: 5.0 / 3, Y: 5.0 / 3}},
+		{"offset square", poly(10, 10, 12, 10, 12, 12, 10, 12), moretypes.Vec{X: 11, Y: 11}},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.p.Centroid()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !got.ApproxEqual(tt.want, 1e-12) {
+			t.Errorf("%s: Centroid = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := line.Centroid(); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("Centroid of a line = %v, want ErrDegenerate", err)
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Polygon
+		pt   moretypes.Vertex
+		want bool
+	}{
+		{"inside", square, v(2, 2), true},
+		{"outside", square, v(5, 2), false},
+		{"on an edge", square, v(4, 2), true},
+		{"on a vertex", square, v(4, 4), true},
+		{"clockwise inside", squareCW, v(1, 3), true},
+		{"level with a vertex, outside", triangle, v(-1, 0), false},
+		{"level with the top vertex, outside", triangle, v(-1, 3), false},
+		{"in the L's notch", lShape, v(3, 3), false},
+		{"in the L's arm", lShape, v(1, 3), true},
+		{"on the notch's corner", lShape, v(2, 2), true},
+		{"level with the notch, right of it", lShape, v(5, 2), false},
+		{"empty polygon", nil, v(0, 0), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.Contains(tt.pt); got != tt.want {
+			t.Errorf("%s: Contains(%v) = %v, want %v", tt.name, tt.pt, got, tt.want)
+		}
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []moretypes.Vertex
+		want   Polygon
+	}{
+		{"square with inside points", []moretypes.Vertex{v(2, 2), v(0, 4), v(4, 0), v(1, 3), v(0, 0), v(4, 4)}, square},
+		{"collinear points on edges are dropped", []moretypes.Vertex{v(0, 0), v(2, 0), v(4, 0), v(4, 4), v(0, 4), v(0, 2)}, square},
+		{"clockwise input", squareCW, square},
+		{"L shape cuts across its notch", lShape, poly(0, 0, 4, 0, 4, 2, 2, 4, 0, 4)},
+		{"duplicates", []moretypes.Vertex{v(1, 1), v(1, 1), v(3, 1), v(2, 5), v(3, 1)}, poly(1, 1, 3, 1, 2, 5)},
+		{"starts at the lowest point", []moretypes.Vertex{v(0, 5), v(5, 0), v(10, 5), v(5, 10)}, poly(5, 0, 10, 5, 5, 10, 0, 5)},
+		{"all on a line", []moretypes.Vertex{v(0, 0), v(1, 1), v(3, 3), v(2, 2)}, poly(0, 0, 3, 3)},
+		{"two points", []moretypes.Vertex{v(2, 0), v(0, 0)}, poly(0, 0, 2, 0)},
+		{"one point", []moretypes.Vertex{v(7, 7), v(7, 7)}, poly(7, 7)},
+		{"none", nil, nil},
+	}
+
+	for _, tt := range tests {
+		got := ConvexHull(tt.points)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ConvexHull = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// the input isn't reordered.
+	in := []moretypes.Vertex{v(4, 4), v(0, 0), v(4, 0)}
+	ConvexHull(in)
+	if !reflect.DeepEqual(in, []moretypes.Vertex{v(4, 4), v(0, 0), v(4, 0)}) {
+		t.Errorf("ConvexHull reordered its input to %v", in)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	seg := func(ax, ay, bx, by int) Segment { return Segment{A: v(ax, ay), B: v(bx, by)} }
+
+	tests := []struct {
+		name       string
+		s, u       Segment
+		intersects bool
+		at         moretypes.Vec
+	}{
+		{"crossing", seg(0, 0, 4, 4), seg(0, 4, 4, 0), true, moretypes.Vec{X: 2, Y: 2}},
+		{"crossing off the grid", seg(0, 0, 3, 1), seg(0, 1, 3, 0), true, moretypes.Vec{X: 1.5, Y: 0.5}},
+		{"T junction", seg(0, 0, 4, 0), seg(2, 0, 2, 5), true, moretypes.Vec{X: 2, Y: 0}},
+		{"shared endpoint", seg(0, 0, 2, 2), seg(2, 2, 5, 0), true, moretypes.Vec{X: 2, Y: 2}},
+		{"apart", seg(0, 0, 1, 1), seg(2, 0, 3, -5), false, moretypes.Vec{}},
+		{"would cross if longer", seg(0, 0, 1, 1), seg(0, 4, 4, 0), false, moretypes.Vec{}},
+		{"parallel", seg(0, 0, 4, 0), seg(0, 1, 4, 1), false, moretypes.Vec{}},
+		{"collinear apart", seg(0, 0, 1, 0), seg(2, 0, 3, 0), false, moretypes.Vec{}},
+		{"collinear overlapping", seg(0, 0, 4, 0), seg(6, 0, 2, 0), true, moretypes.Vec{X: 2, Y: 0}},
+		{"collinear touching", seg(0, 0, 2, 0), seg(2, 0, 3, 0), true, moretypes.Vec{X: 2, Y: 0}},
+		{"contained", seg(5, 5, 0, 0), seg(1, 1, 2, 2), true, moretypes.Vec{X: 2, Y: 2}},
+		{"collinear beyond the end, other end off the line", seg(0, 0, 2, 0), seg(3, 0, 4, 1), false, moretypes.Vec{}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.s.Intersects(tt.u); got != tt.intersects {
+			t.Errorf("%s: Intersects = %v, want %v", tt.name, got, tt.intersects)
+		}
+		if got := tt.u.Intersects(tt.s); got != tt.intersects {
+			t.Errorf("%s: Intersects isn't symmetric", tt.name)
+		}
+		at, ok := tt.s.Intersection(tt.u)
+		if ok != tt.intersects || !at.ApproxEqual(tt.at, 1e-12) {
+			t.Errorf("%s: Intersection = %+v, %v, want %+v, %v", tt.name, at, ok, tt.at, tt.intersects)
+		}
+	}
+
+	if got := seg(0, 0, 3, 4).Length(); got != 5 {
+		t.Errorf("Length = %g, want 5", got)
+	}
+}
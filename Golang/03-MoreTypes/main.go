@@ -1,9 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
+	"os"
 	"strings"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
 )
 
 /*
@@ -80,34 +85,24 @@ import (
  * If you only want the index, you can omit the second variable.
  */
 
-func printSlice(slice []int) {
-	fmt.Printf("\nlen = %d, cap = %d %v", len(slice), cap(slice), slice)
+func printSlice(out *report.Printer, expr string, slice []int) {
+	out.Printf(report.Record{Name: "slice", Inputs: map[string]any{"expr": expr}, Result: sliceInfo(slice), Type: "[]int"},
+		"\nlen = %d, cap = %d %v", len(slice), cap(slice), slice)
 }
 
-type Vertex struct {
-	X int
-	Y int
+// sliceInfo is the JSON result for examples that show a slice's length and capacity.
+func sliceInfo(slice []int) map[string]any {
+	return map[string]any{"len": len(slice), "cap": cap(slice), "values": slice}
 }
 
-/**
- * Exercise - Implement the Pic function. It should return a slice of length dy, each element of which is a slice of dx
- * 8-bit unsigned integers. When you run the program, it will display your picture, interpreting the integers as grayscale
- * (well, bluescale) values.
- *
- * The choice of image is up to you. Interesting functions include (x+y)/2, x*y, and x^y.
- */
-
-func Pic(dx, dy int) [][]uint8 {
-	var pic [][]uint8 = make([][]uint8, dy)
-
-	for y := 0; y < dy; y++ {
-		for x := 0; x < dx; x++ {
-			pic[y] = append(pic[y], uint8((x*x + y*y)))
-		}
-	}
+// the Vertex and Coord types and the Pic function from this section live in the importable "moretypes" package.
+// fields of a struct from another package are set by name below (Vertex{X: 1, Y: 2}), as go vet recommends.
+// Vertex has a String method (it implements fmt.Stringer), so vertices and pointers to them print as (X,Y)
+// instead of the default {X Y} and &{X Y}.
 
-	return pic
-}
+// plainVertex is a Vertex without the String method, so a *plainVertex still prints with its & and shows
+// that p4 below is a pointer.
+type plainVertex moretypes.Vertex
 
 /*
  * A map maps keys to values (like a Python dictionary).
@@ -127,83 +122,70 @@ func Pic(dx, dy int) [][]uint8 {
  * If key is not in the map, then elem is the zero value for the map's eleemnt type.
  */
 
-type Coord struct {
-	Lat, Long float64
-}
-
 /*
  * Functions are values too which can be passed around like other values.
  * Function values may be used as function arguments and return values.
  *
  * Some functions may be closures, which are function values that reference variables from outside their body.
  * The function may access and assign to the referenced variables; in this sense the function is "bound" to the variables.
-
+ * See moretypes.Adder, moretypes.Compute and moretypes.Fibonacci.
  */
 
-func adder() func(int) int {
-	sum := 0 // captured variable
-	return func(x int) int {
-		sum += x
-		return sum
-	}
-}
+// pass -format=json to print every example as a structured report.Record instead of text.
+var format report.Format = report.Text
 
-func compute(fn func(float64, float64) float64) float64 {
-	return fn(3, 4)
-}
-
-func fibonnaci() func() int {
-	a, b := 0, 1
+func main() {
+	flag.Var(&format, "format", "output format: text or json")
+	flag.Parse()
 
-	return func() int {
-		a, b = b, a+b
-		return a
-	}
-}
+	out := report.NewPrinter(os.Stdout, format)
 
-func main() {
 	// pointers
 	i, j := 42, 27
 
 	p := &i // point to i
 	*p = 21 // set i through the pointer
-	fmt.Println("i:", i)
+	out.Println(report.Record{Name: "pointer", Inputs: map[string]any{"i": 42}, Result: i}, "i:", i)
 
 	p = &j      // point to j
 	*p = *p * 2 // set j through the pointer
-	fmt.Println("j:", j)
+	out.Println(report.Record{Name: "pointer", Inputs: map[string]any{"j": 27}, Result: j}, "j:", j)
 
 	// structs
-	v := Vertex{1, 2}
+	v := moretypes.Vertex{X: 1, Y: 2}
 	v.X = 4
-	fmt.Println("v:", v)
+	out.Println(report.Record{Name: "struct", Result: v}, "v:", v)
 
 	// struct pointers
 	vp := &v
 	vp.Y = 5
-	fmt.Println("v':", v)
+	out.Println(report.Record{Name: "struct pointer", Result: v}, "v':", v)
 
 	// struct literals
-	v1 := Vertex{1, 2}  // has type Vertex
-	v2 := Vertex{X: 1}  // Y:0 is implicit
-	v3 := Vertex{}      // X:0 and Y:0
-	p4 := &Vertex{1, 2} // has type *Vertex
+	v1 := moretypes.Vertex{X: 1, Y: 2}  // has type Vertex
+	v2 := moretypes.Vertex{X: 1}        // Y:0 is implicit
+	v3 := moretypes.Vertex{}            // X:0 and Y:0
+	p4 := &moretypes.Vertex{X: 1, Y: 2} // has type *Vertex
 
-	fmt.Println("v1:", v1, "v2:", v2, "v3:", v3, "p4:", p4)
+	out.Println(report.Record{
+		Name:   "struct literals",
+		Result: map[string]any{"v1": v1, "v2": v2, "v3": v3, "p4": p4},
+		Type:   fmt.Sprintf("%T, %T, %T, %T", v1, v2, v3, p4),
+	}, "v1:", v1, "v2:", v2, "v3:", v3, "p4:", (*plainVertex)(p4))
 
 	// arrays
 	var a [2]string
 	a[0] = "Hello"
 	a[1] = "World"
-	fmt.Println("array:", a)
-	fmt.Println("array length:", len(a))
+	out.Println(report.Record{Name: "array", Result: a}, "array:", a)
+	out.Println(report.Record{Name: "array length", Result: len(a)}, "array length:", len(a))
 
 	primes := [6]int{2, 3, 5, 7, 11, 13}
-	fmt.Println("primes:", primes)
+	out.Println(report.Record{Name: "primes", Result: primes}, "primes:", primes)
 
 	// slices
 	var s []int = primes[1:4]
-	fmt.Println("primes slice:", s)
+	out.Println(report.Record{Name: "slice", Inputs: map[string]any{"low": 1, "high": 4}, Result: s}, "primes slice:", s)
 
 	names := [4]string{
 		"John",
@@ -211,25 +193,25 @@ func main() {
 		"George",
 		"Ringo",
 	}
-	fmt.Println("\nnames array:", names)
+	out.Println(report.Record{Name: "names array", Result: names}, "\nnames array:", names)
 
 	b := names[0:2]
 	c := names[1:3]
-	fmt.Println("b slice:", b)
-	fmt.Println("c slice:", c)
+	out.Println(report.Record{Name: "b slice", Inputs: map[string]any{"low": 0, "high": 2}, Result: b}, "b slice:", b)
+	out.Println(report.Record{Name: "c slice", Inputs: map[string]any{"low": 1, "high": 3}, Result: c}, "c slice:", c)
 
 	c[0] = "XXX"
-	fmt.Println("\nafter modification...")
-	fmt.Println("names array:", names)
-	fmt.Println("b slice:", b)
-	fmt.Println("c slice:", c)
+	out.Textln("\nafter modification...")
+	out.Println(report.Record{Name: "names array after modification", Result: names}, "names array:", names)
+	out.Println(report.Record{Name: "b slice after modification", Result: b}, "b slice:", b)
+	out.Println(report.Record{Name: "c slice after modification", Result: c}, "c slice:", c)
 
 	// slice literals
 	q := []int{2, 3, 5, 7, 11, 13}
-	fmt.Println("\nslice literal q:", q)
+	out.Println(report.Record{Name: "slice literal", Result: q}, "\nslice literal q:", q)
 
 	r := []bool{true, false, true, true, false, true}
-	fmt.Println("slice literal r:", r)
+	out.Println(report.Record{Name: "slice literal", Result: r}, "slice literal r:", r)
 
 	// slices continued
 	var d [10]int
@@ -239,39 +221,39 @@ func main() {
 	d4 := d[:]
 
 	// d === d1 === d2 === d3 === d4
-	fmt.Println("\nd:", d)
-	fmt.Println("d1:", d1)
-	fmt.Println("d2:", d2)
-	fmt.Println("d3:", d3)
-	fmt.Println("d4:", d4)
+	out.Println(report.Record{Name: "slice defaults", Inputs: map[string]any{"expr": "d"}, Result: d}, "\nd:", d)
+	out.Println(report.Record{Name: "slice defaults", Inputs: map[string]any{"expr": "d[0:10]"}, Result: d1}, "d1:", d1)
+	out.Println(report.Record{Name: "slice defaults", Inputs: map[string]any{"expr": "d[:10]"}, Result: d2}, "d2:", d2)
+	out.Println(report.Record{Name: "slice defaults", Inputs: map[string]any{"expr": "d[0:]"}, Result: d3}, "d3:", d3)
+	out.Println(report.Record{Name: "slice defaults", Inputs: map[string]any{"expr": "d[:]"}, Result: d4}, "d4:", d4)
 
 	t := []int{2, 3, 5, 7, 11, 13}
-	printSlice(t)
+	printSlice(out, "t", t)
 
 	t = t[:0]
-	printSlice(t)
+	printSlice(out, "t[:0]", t)
 
 	t = t[:4]
-	printSlice(t)
+	printSlice(out, "t[:4]", t)
 
 	t = t[2:]
-	printSlice(t)
+	printSlice(out, "t[2:]", t)
 
 	var nilSlice []int
-	fmt.Println("\n\nnilSlice:", nilSlice, len(nilSlice), cap(nilSlice))
+	out.Println(report.Record{Name: "nil slice", Result: sliceInfo(nilSlice), Type: "[]int"}, "\n\nnilSlice:", nilSlice, len(nilSlice), cap(nilSlice))
 
 	// slices with make
 	e := make([]int, 5)    // len(e) = 5
 	f := make([]int, 0, 5) // len(f) = 0, cap(f) = 5
 
-	fmt.Println("\ne:", e, len(e), cap(e))
-	fmt.Println("f:", f, len(f), cap(f))
+	out.Println(report.Record{Name: "make", Inputs: map[string]any{"len": 5}, Result: sliceInfo(e), Type: "[]int"}, "\ne:", e, len(e), cap(e))
+	out.Println(report.Record{Name: "make", Inputs: map[string]any{"len": 0, "cap": 5}, Result: sliceInfo(f), Type: "[]int"}, "f:", f, len(f), cap(f))
 
 	f = f[:cap(f)] // len(f) = 5, cap(f) = 5
-	fmt.Println("f:", f, len(f), cap(f))
+	out.Println(report.Record{Name: "reslice", Inputs: map[string]any{"expr": "f[:cap(f)]"}, Result: sliceInfo(f), Type: "[]int"}, "f:", f, len(f), cap(f))
 
 	f = f[1:]
-	fmt.Println("f:", f, len(f), cap(f), "\n")
+	out.Printf(report.Record{Name: "reslice", Inputs: map[string]any{"expr": "f[1:]"}, Result: sliceInfo(f), Type: "[]int"}, "f: %v %d %d \n\n", f, len(f), cap(f))
 
 	// slices of slices
 	board := [][]string{
@@ -286,125 +268,145 @@ func main() {
 	board[1][0] = "O"
 	board[0][2] = "X"
 
+	var rendered strings.Builder
 	for i := 0; i < len(board); i++ {
-		fmt.Printf("%s\n", strings.Join(board[i], " "))
+		fmt.Fprintf(&rendered, "%s\n", strings.Join(board[i], " "))
 	}
+	out.Printf(report.Record{Name: "slices of slices", Result: board}, "%s", rendered.String())
 
 	// appending to a slice
 	var s2 []int
-	printSlice(s2)
+	printSlice(out, "var s2 []int", s2)
 
 	s2 = append(s2, 0)
-	printSlice(s2)
+	printSlice(out, "append(s2, 0)", s2)
 
 	s2 = append(s2, 1, 2, 3, 4)
-	printSlice(s2)
+	printSlice(out, "append(s2, 1, 2, 3, 4)", s2)
 
-	fmt.Println()
+	out.Textln()
 
 	// range looping
 
 	var pow []int = []int{1, 2, 4, 8, 16, 32, 64, 128}
 
 	for idx, el := range pow {
-		fmt.Printf("\n2**%d = %d", idx, el)
+		out.Printf(report.Record{Name: "range", Inputs: map[string]any{"index": idx}, Result: el}, "\n2**%d = %d", idx, el)
 	}
 
-	fmt.Println()
+	out.Textln()
 
 	var pow2 []int = make([]int, 10)
 	for idx := range pow2 {
 		pow2[idx] = 1 << uint(idx) // == 2**i
 	}
 
-	for _, el := range pow2 {
-		fmt.Printf("\n%d", el)
+	for idx, el := range pow2 {
+		out.Printf(report.Record{Name: "range value only", Inputs: map[string]any{"index": idx}, Result: el}, "\n%d", el)
 	}
 
-	fmt.Println()
+	out.Textln()
 
 	// exercise
-	picture := Pic(8, 8)
+	picture := moretypes.Pic(8, 8)
 
+	var rows strings.Builder
 	for _, line := range picture {
-		fmt.Println(line)
+		fmt.Fprintln(&rows, line)
 	}
+	out.Printf(report.Record{Name: "pic", Inputs: map[string]any{"dx": 8, "dy": 8}, Result: picture}, "%s", rows.String())
 
 	// maps
-	var Map map[string]Coord = make(map[string]Coord)
-	Map["Bell Labs"] = Coord{
-		40.68433, -74.39967,
+	var Map map[string]moretypes.Coord = make(map[string]moretypes.Coord)
+	Map["Bell Labs"] = moretypes.Coord{
+		Lat: 40.68433, Long: -74.39967,
 	}
 
-	fmt.Println("\nmap:", Map)
+	out.Println(report.Record{Name: "map", Result: Map}, "\nmap:", Map)
 
 	// map literals
-	Map2 := map[string]Coord{
-		"Google": Coord{
-			37.42202, -122.08408,
+	Map2 := map[string]moretypes.Coord{
+		"Google": moretypes.Coord{
+			Lat: 37.42202, Long: -122.08408,
 		},
-		"Apple": Coord{
-			37.33182, -122.03118,
+		"Apple": moretypes.Coord{
+			Lat: 37.33182, Long: -122.03118,
 		},
 	}
 
-	Map3 := map[string]Coord{
-		"New York City":   {40.71278, -74.00594},
-		"Los Angeles":     {34.05223, -118.24368},
-		"Chicago":         {41.87811, -87.62980},
-		"Houston":         {29.76043, -95.36980},
-		"Philadelphia":    {39.95233, -75.16379},
-		"Pittsburgh":      {40.44062, -79.99589},
-		"San Francisco":   {37.77493, -122.41942},
-		"Washington D.C.": {38.90719, -77.03687},
+	Map3 := map[string]moretypes.Coord{
+		"New York City":   {Lat: 40.71278, Long: -74.00594},
+		"Los Angeles":     {Lat: 34.05223, Long: -118.24368},
+		"Chicago":         {Lat: 41.87811, Long: -87.62980},
+		"Houston":         {Lat: 29.76043, Long: -95.36980},
+		"Philadelphia":    {Lat: 39.95233, Long: -75.16379},
+		"Pittsburgh":      {Lat: 40.44062, Long: -79.99589},
+		"San Francisco":   {Lat: 37.77493, Long: -122.41942},
+		"Washington D.C.": {Lat: 38.90719, Long: -77.03687},
 	}
 
-	fmt.Println("map literal:", Map2)
-	fmt.Println("map literal 2:", Map3)
+	out.Println(report.Record{Name: "map literal", Result: Map2}, "map literal:", Map2)
+	out.Println(report.Record{Name: "map literal", Result: Map3}, "map literal 2:", Map3)
 
 	// mutating maps
 	Map4 := make(map[string]int)
 
 	Map4["Answer"] = 42
-	fmt.Println("\nThe value:", Map4["Answer"])
+	out.Println(report.Record{Name: "map insert", Inputs: map[string]any{"key": "Answer", "value": 42}, Result: Map4["Answer"]}, "\nThe value:", Map4["Answer"])
 
 	Map4["Answer"] = 48
-	fmt.Println("The value:", Map4["Answer"])
+	out.Println(report.Record{Name: "map update", Inputs: map[string]any{"key": "Answer", "value": 48}, Result: Map4["Answer"]}, "The value:", Map4["Answer"])
 
 	delete(Map4, "Answer")
-	fmt.Println("The value:", Map4["Answer"])
+	out.Println(report.Record{Name: "map delete", Inputs: map[string]any{"key": "Answer"}, Result: Map4["Answer"]}, "The value:", Map4["Answer"])
 
 	val, ok := Map4["Answer"]
-	fmt.Println("The value:", val, "Present?", ok, "\n")
+	out.Printf(report.Record{
+		Name:   "map lookup",
+		Inputs: map[string]any{"key": "Answer"},
+		Result: map[string]any{"value": val, "present": ok},
+		Type:   fmt.Sprintf("%T, %T", val, ok),
+	}, "The value: %d Present? %t \n\n", val, ok)
 
 	// functions
 	hypot := func(x, y float64) float64 {
 		return math.Sqrt(x*x + y*y)
 	}
 
-	fmt.Println(hypot(5, 12))
-	fmt.Println(compute(hypot))
+	h := hypot(5, 12)
+	out.Println(report.Record{Name: "function value", Inputs: map[string]any{"fn": "hypot", "x": 5, "y": 12}, Result: h}, h)
 
-	fmt.Println(compute(math.Pow), "\n")
+	h = moretypes.Compute(hypot)
+	out.Println(report.Record{Name: "compute", Inputs: map[string]any{"fn": "hypot"}, Result: h}, h)
+
+	pw := moretypes.Compute(math.Pow)
+	out.Printf(report.Record{Name: "compute", Inputs: map[string]any{"fn": "math.Pow"}, Result: pw}, "%v \n\n", pw)
 
 	// closures
-	var pos, neg func(int) int = adder(), adder()
+	var pos, neg func(int) int = moretypes.Adder(), moretypes.Adder()
 
 	for i := 0; i < 10; i++ {
-		fmt.Println(
+		ps, ns := pos(i), neg(-i)
+		out.Println(report.Record{
+			Name:   "closures",
+			Inputs: map[string]any{"pos": i, "neg": -i},
+			Result: map[string]any{"pos": ps, "neg": ns},
+			Type:   "int, int",
+		},
 			i,
 			"->",
-			"pos:", pos(i),
-			"neg:", neg(-i),
+			"pos:", ps,
+			"neg:", ns,
 		)
 	}
 
-	fmt.Println()
+	out.Textln()
 
 	// Fibonacci closure
-	var fib func() int = fibonnaci()
+	var fib func() int = moretypes.Fibonacci()
 	for i := 0; i < 10; i++ {
-		fmt.Println(fib())
+		n := fib()
+		out.Println(report.Record{Name: "fibonacci", Inputs: map[string]any{"call": i + 1}, Result: n}, n)
 	}
 
 }
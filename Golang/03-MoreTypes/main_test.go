@@ -0,0 +1,11 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/golden"
+)
+
+func TestREADMEOutput(t *testing.T) {
+	golden.Run(t, ".", nil)
+}
@@ -0,0 +1,176 @@
+package moretypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// String returns v in the form "(X,Y)", e.g. "(4,5)". Because a *Vertex has the methods of a Vertex too,
+// fmt prints pointers to vertices the same way.
+func (v Vertex) String() string {
+	return "(" + strconv.Itoa(v.X) + "," + strconv.Itoa(v.Y) + ")"
+}
+
+// MarshalText encodes v as String does. It implements encoding.TextMarshaler, which also lets vertices be
+// map keys in JSON.
+func (v Vertex) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText parses text with ParseVertex. It implements encoding.TextUnmarshaler.
+func (v *Vertex) UnmarshalText(text []byte) error {
+	w, err := ParseVertex(string(text))
+	if err != nil {
+		return err
+	}
+	*v = w
+
+	return nil
+}
+
+// vertexJSON is the JSON object form of a Vertex, the same as encoding/json would produce for it without
+// the text methods.
+type vertexJSON struct {
+	X int
+	Y int
+}
+
+// MarshalJSON encodes v as the object {"X":4,"Y":5}, as it was before Vertex had text methods, so JSON
+// output doesn't change. It implements json.Marshaler.
+func (v Vertex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vertexJSON(v))
+}
+
+// UnmarshalJSON decodes either the object form {"X":4,"Y":5} (field names in any case, missing fields
+// zero) or the text form "(4,5)". Like encoding/json's own decoding, it leaves v unchanged for null. It
+// implements json.Unmarshaler.
+func (v *Vertex) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(s))
+	}
+
+	var obj vertexJSON
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&obj); err != nil {
+		return fmt.Errorf("moretypes: vertex must be an object like {\"X\":4,\"Y\":5} or a string like \"(4,5)\": %w", err)
+	}
+	*v = Vertex(obj)
+
+	return nil
+}
+
+// VertexError reports why ParseVertex rejected its input.
+type VertexError struct {
+	Input  string // the text being parsed
+	Offset int    // byte offset in Input where the problem was found
+	Msg    string
+}
+
+func (e *VertexError) Error() string {
+	return fmt.Sprintf("moretypes: parsing vertex %q: at offset %d: %s", e.Input, e.Offset, e.Msg)
+}
+
+// ParseVertex parses a vertex in the form String produces, "(X,Y)", where X and Y are decimal integers
+// that may have a sign. Spaces and tabs are allowed around the numbers and the parentheses. Errors are
+// *VertexErrors giving the offset of the problem.
+func ParseVertex(s string) (Vertex, error) {
+	p := vertexParser{s: s}
+
+	p.space()
+	if !p.consume('(') {
+		return Vertex{}, p.fail("expected '('")
+	}
+	x, err := p.int("X")
+	if err != nil {
+		return Vertex{}, err
+	}
+	if !p.consume(',') {
+		return Vertex{}, p.fail("expected ',' after X")
+	}
+	y, err := p.int("Y")
+	if err != nil {
+		return Vertex{}, err
+	}
+	if !p.consume(')') {
+		return Vertex{}, p.fail("expected ')' after Y")
+	}
+	p.space()
+	if p.i < len(s) {
+		return Vertex{}, p.fail("unexpected text after ')'")
+	}
+
+	return Vertex{X: x, Y: y}, nil
+}
+
+// vertexParser scans the input to ParseVertex.
+type vertexParser struct {
+	s string
+	i int
+}
+
+// space skips spaces and tabs.
+func (p *vertexParser) space() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// consume skips spaces, then the byte c if it's next, and reports whether it was.
+func (p *vertexParser) consume(c byte) bool {
+	p.space()
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+
+	return false
+}
+
+// int parses the coordinate called name.
+func (p *vertexParser) int(name string) (int, error) {
+	p.space()
+	start := p.i
+	if p.i < len(p.s) && (p.s[p.i] == '-' || p.s[p.i] == '+') {
+		p.i++
+	}
+	for p.i < len(p.s) && '0' <= p.s[p.i] && p.s[p.i] <= '9' {
+		p.i++
+	}
+
+	text := p.s[start:p.i]
+	if text == "" || text == "-" || text == "+" {
+		p.i = start
+		return 0, p.fail("expected an integer for " + name)
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		// the digits are valid, so the number is out of range.
+		return 0, &VertexError{Input: p.s, Offset: start, Msg: fmt.Sprintf("%s %s is out of range", name, text)}
+	}
+
+	return n, nil
+}
+
+// fail returns an error at the current offset, describing what was found there.
+func (p *vertexParser) fail(msg string) error {
+	if p.i >= len(p.s) {
+		msg += ", found end of input"
+	} else {
+		r, _ := utf8.DecodeRuneInString(p.s[p.i:])
+		msg += fmt.Sprintf(", found %q", r)
+	}
+
+	return &VertexError{Input: p.s, Offset: p.i, Msg: msg}
+}
@@ -0,0 +1,153 @@
+package moretypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestVertexString(t *testing.T) {
+	v := Vertex{X: 4, Y: -5}
+	if got := fmt.Sprint(v, &v); got != "(4,-5) (4,-5)" {
+		t.Errorf("Sprint(v, &v) = %q, want %q", got, "(4,-5) (4,-5)")
+	}
+	if got := fmt.Sprintf("%v", []Vertex{{}, {X: 1, Y: 2}}); got != "[(0,0) (1,2)]" {
+		t.Errorf("Sprintf of a slice = %q", got)
+	}
+}
+
+func TestParseVertex(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Vertex
+		offset int    // of the error, or -1 if there's none
+		msg    string // part of the error
+	}{
+		{"(4,5)", Vertex{X: 4, Y: 5}, -1, ""},
+		{" ( -4 ,\t+5 ) ", Vertex{X: -4, Y: 5}, -1, ""},
+		{"(0,0)", Vertex{}, -1, ""},
+		{fmt.Sprintf("(%d,%d)", math.MinInt, math.MaxInt), Vertex{X: math.MinInt, Y: math.MaxInt}, -1, ""},
+		{"", Vertex{}, 0, "expected '(', found end of input"},
+		{"4,5", Vertex{}, 0, `expected '(', found '4'`},
+		{"(4 5)", Vertex{}, 3, `expected ',' after X, found '5'`},
+		{"(4,)", Vertex{}, 3, `expected an integer for Y, found ')'`},
+		{"(x,5)", Vertex{}, 1, `expected an integer for X, found 'x'`},
+		{"(-,5)", Vertex{}, 1, "expected an integer for X"},
+		{"(4,5", Vertex{}, 4, "expected ')' after Y, found end of input"},
+		{"(4,5]", Vertex{}, 4, `found ']'`},
+		{"(4,5) x", Vertex{}, 6, "unexpected text after ')'"},
+		{"(4.5,1)", Vertex{}, 2, `expected ',' after X, found '.'`},
+		{"(4,5é)", Vertex{}, 4, `found 'é'`},
+		{"(99999999999999999999,1)", Vertex{}, 1, "X 99999999999999999999 is out of range"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVertex(tt.in)
+		if tt.offset < 0 {
+			if err != nil || got != tt.want {
+				t.Errorf("ParseVertex(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+			continue
+		}
+
+		var verr *VertexError
+		if !errors.As(err, &verr) {
+			t.Errorf("ParseVertex(%q) error = %v, want a *VertexError", tt.in, err)
+			continue
+		}
+		if verr.Offset != tt.offset || !strings.Contains(verr.Msg, tt.msg) {
+			t.Errorf("ParseVertex(%q) error at %d: %q, want at %d: %q", tt.in, verr.Offset, verr.Msg, tt.offset, tt.msg)
+		}
+	}
+}
+
+func TestVertexTextRoundTrip(t *testing.T) {
+	for _, v := range []Vertex{{}, {X: 4, Y: 5}, {X: -1, Y: math.MaxInt}} {
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Vertex
+		if err := got.UnmarshalText(text); err != nil || got != v {
+			t.Errorf("text round trip of %v = %v, %v", v, got, err)
+		}
+		if back, err := ParseVertex(v.String()); err != nil || back != v {
+			t.Errorf("ParseVertex(%q) = %v, %v", v.String(), back, err)
+		}
+	}
+}
+
+func TestVertexJSON(t *testing.T) {
+	type config struct {
+		Origin Vertex
+		Path   []Vertex
+		Labels map[Vertex]string
+		Cursor *Vertex
+	}
+	in := config{
+		Origin: Vertex{X: 1, Y: 2},
+		Path:   []Vertex{{}, {X: -3, Y: 4}},
+		Labels: map[Vertex]string{{X: 4, Y: 5}: "home"},
+		Cursor: &Vertex{X: 7, Y: 8},
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// objects as before, and the text form for map keys.
+	want := `{"Origin":{"X":1,"Y":2},"Path":[{"X":0,"Y":0},{"X":-3,"Y":4}],"Labels":{"(4,5)":"home"},"Cursor":{"X":7,"Y":8}}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(out) != fmt.Sprint(in) || *out.Cursor != *in.Cursor {
+		t.Errorf("JSON round trip = %+v, want %+v", out, in)
+	}
+
+	decodes := []struct {
+		in   string
+		want Vertex
+		ok   bool
+	}{
+		{`"(4,5)"`, Vertex{X: 4, Y: 5}, true},
+		{`{"x": 4, "y": 5}`, Vertex{X: 4, Y: 5}, true},
+		{`{"Y": 5}`, Vertex{Y: 5}, true},
+		{`"4,5"`, Vertex{}, false},
+		{`{"X": 1, "Z": 2}`, Vertex{}, false},
+		{`{"X": 1.5}`, Vertex{}, false},
+		{`[4, 5]`, Vertex{}, false},
+	}
+	for _, tt := range decodes {
+		var v Vertex
+		err := json.Unmarshal([]byte(tt.in), &v)
+		if (err == nil) != tt.ok || tt.ok && v != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v (ok %v)", tt.in, v, err, tt.want, tt.ok)
+		}
+	}
+
+	// null leaves a vertex as it was, whether it's decoded directly or as a field.
+	v := Vertex{X: 4, Y: 5}
+	if err := json.Unmarshal([]byte(`null`), &v); err != nil || v != (Vertex{X: 4, Y: 5}) {
+		t.Errorf("Unmarshal(null) = %v, %v, want (4,5) unchanged", v, err)
+	}
+	out = config{Origin: Vertex{X: 1, Y: 2}}
+	if err := json.Unmarshal([]byte(`{"Origin": null}`), &out); err != nil || out.Origin != (Vertex{X: 1, Y: 2}) {
+		t.Errorf("Unmarshal({\"Origin\": null}) = %+v, %v, want Origin unchanged", out, err)
+	}
+}
+
+func TestVertexErrorMessage(t *testing.T) {
+	_, err := ParseVertex("(1;2)")
+	want := `moretypes: parsing vertex "(1;2)": at offset 2: expected ',' after X, found ';'`
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,175 @@
+// Package moretypes holds the types and functions from the "More Types" section of the Go tour
+// so they can be imported and reused outside of the lesson's demo program.
+package moretypes
+
+import (
+	"fmt"
+	"math"
+)
+
+// Vertex is a point on an integer grid.
+type Vertex struct {
+	X int
+	Y int
+}
+
+/**
+ * Exercise - Implement the Pic function. It should return a slice of length dy, each element of which is a slice of dx
+ * 8-bit unsigned integers. When you run the program, it will display your picture, interpreting the integers as grayscale
+ * (well, bluescale) values.
+ *
+ * The choice of image is up to you. Interesting functions include (x+y)/2, x*y, and x^y.
+ */
+
+// Pic returns a dy-by-dx picture whose pixel values are x*x + y*y.
+func Pic(dx, dy int) [][]uint8 {
+	return PicFunc(dx, dy, SumOfSquares)
+}
+
+// PixelFunc computes the value of the pixel at (x, y) in a dx-by-dy picture.
+// PicMode decides what happens to values outside 0-255.
+type PixelFunc func(x, y, dx, dy int) int
+
+// SumOfSquares is Pic's original pixel function, x*x + y*y.
+func SumOfSquares(x, y, dx, dy int) int {
+	return x*x + y*y
+}
+
+// Mode is how PicMode turns pixel values outside 0-255 into uint8s.
+type Mode string
+
+const (
+	Wrap      Mode = "wrap"      // keep the low 8 bits, as uint8(v) does (256 becomes 0)
+	Clamp     Mode = "clamp"     // values below 0 become 0 and values above 255 become 255
+	Normalize Mode = "normalize" // scale the picture's smallest value to 0 and its largest to 255
+)
+
+// String returns the name of the mode.
+func (m *Mode) String() string {
+	if m == nil || *m == "" {
+		return string(Wrap)
+	}
+
+	return string(*m)
+}
+
+// Set parses s as a Mode, so a Mode can be bound to a flag.
+func (m *Mode) Set(s string) error {
+	switch Mode(s) {
+	case Wrap, Clamp, Normalize:
+		*m = Mode(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q (want %s, %s or %s)", s, Wrap, Clamp, Normalize)
+	}
+}
+
+// PicFunc returns a dy-by-dx picture whose pixel values are computed by fn.
+// Values outside 0-255 wrap around.
+func PicFunc(dx, dy int, fn PixelFunc) [][]uint8 {
+	return PicMode(dx, dy, fn, Wrap)
+}
+
+// PicMode returns a dy-by-dx picture whose pixel values are computed by fn and brought into 0-255
+// according to mode. An empty Mode means Wrap.
+func PicMode(dx, dy int, fn PixelFunc, mode Mode) [][]uint8 {
+	if mode == Normalize {
+		return normalize(dx, dy, fn)
+	}
+
+	var pic [][]uint8 = make([][]uint8, dy)
+
+	for y := 0; y < dy; y++ {
+		for x := 0; x < dx; x++ {
+			v := fn(x, y, dx, dy)
+			if mode == Clamp {
+				v = min(max(v, 0), 255)
+			}
+			pic[y] = append(pic[y], uint8(v))
+		}
+	}
+
+	return pic
+}
+
+// normalize computes every pixel first, then scales the values linearly so the smallest becomes 0 and
+// the largest 255. A picture where every value is the same becomes all zeros.
+func normalize(dx, dy int, fn PixelFunc) [][]uint8 {
+	raw := make([][]int, dy)
+	lo, hi := math.MaxInt, math.MinInt
+	for y := range raw {
+		raw[y] = make([]int, dx)
+		for x := range raw[y] {
+			v := fn(x, y, dx, dy)
+			raw[y][x] = v
+			lo, hi = min(lo, v), max(hi, v)
+		}
+	}
+
+	pic := make([][]uint8, dy)
+	for y, row := range raw {
+		pic[y] = make([]uint8, dx)
+		if hi == lo {
+			continue
+		}
+		for x, v := range row {
+			// float64 so the subtraction and the multiplication can't overflow.
+			pic[y][x] = uint8(math.Round((float64(v) - float64(lo)) * 255 / (float64(hi) - float64(lo))))
+		}
+	}
+
+	return pic
+}
+
+// Coord is a geographic coordinate in decimal degrees.
+type Coord struct {
+	Lat, Long float64
+}
+
+/*
+ * Functions are values too which can be passed around like other values.
+ * Function values may be used as function arguments and return values.
+ *
+ * Some functions may be closures, which are function values that reference variables from outside their body.
+ * The function may access and assign to the referenced variables; in this sense the function is "bound" to the variables.
+ */
+
+// Adder returns a closure that adds each argument to a running sum and returns the new sum.
+func Adder() func(int) int {
+	sum := 0 // captured variable
+	return func(x int) int {
+		sum += x
+		return sum
+	}
+}
+
+// Compute calls fn with the arguments 3 and 4.
+func Compute(fn func(float64, float64) float64) float64 {
+	return fn(3, 4)
+}
+
+// computeFuncs are the functions ComputeFunc knows by name.
+var computeFuncs = map[string]func(float64, float64) float64{
+	"hypot": math.Hypot,
+	"pow":   math.Pow,
+}
+
+// ComputeFunc returns the function called name, hypot or pow, for passing to Compute.
+func ComputeFunc(name string) (func(float64, float64) float64, error) {
+	fn, ok := computeFuncs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q (want hypot or pow)", name)
+	}
+
+	return fn, nil
+}
+
+// Fibonacci returns a closure that yields successive Fibonacci numbers, starting at 1.
+func Fibonacci() func() int {
+	a, b := 0, 1
+
+	return func() int {
+		a, b = b, a+b
+		return a
+	}
+}
@@ -0,0 +1,61 @@
+package moretypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPicMode(t *testing.T) {
+	// a 4x1 picture with values -10, 100, 300 and 600.
+	fn := func(x, y, dx, dy int) int {
+		return []int{-10, 100, 300, 600}[x]
+	}
+
+	tests := []struct {
+		mode Mode
+		want []uint8
+	}{
+		{Wrap, []uint8{246, 100, 44, 88}},
+		{"", []uint8{246, 100, 44, 88}},
+		{Clamp, []uint8{0, 100, 255, 255}},
+		{Normalize, []uint8{0, 46, 130, 255}},
+	}
+
+	for _, tt := range tests {
+		if got := PicMode(4, 1, fn, tt.mode); !reflect.DeepEqual(got, [][]uint8{tt.want}) {
+			t.Errorf("PicMode(%q) = %v, want %v", tt.mode, got, [][]uint8{tt.want})
+		}
+	}
+}
+
+func TestPicModeNormalizeFlat(t *testing.T) {
+	flat := func(x, y, dx, dy int) int { return 1000 }
+
+	if got, want := PicMode(2, 2, flat, Normalize), [][]uint8{{0, 0}, {0, 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PicMode of a flat picture = %v, want %v", got, want)
+	}
+}
+
+func TestPicUnchanged(t *testing.T) {
+	// Pic keeps its original wrapping behaviour: 16*16 = 256 wraps to 0.
+	if got := Pic(17, 1)[0][16]; got != 0 {
+		t.Errorf("Pic(17, 1)[0][16] = %d, want 0", got)
+	}
+}
+
+func TestComputeFunc(t *testing.T) {
+	for name, want := range map[string]float64{"hypot": 5, "pow": 81} {
+		fn, err := ComputeFunc(name)
+		if err != nil {
+			t.Errorf("ComputeFunc(%q): %v", name, err)
+			continue
+		}
+		if got := Compute(fn); got != want {
+			t.Errorf("Compute(%s) = %g, want %g", name, got, want)
+		}
+	}
+
+	if _, err := ComputeFunc("max"); err == nil || err.Error() != `unknown function "max" (want hypot or pow)` {
+		t.Errorf("ComputeFunc(max) error = %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package moretypes
+
+import "math"
+
+/*
+ * Vertex as a 2D vector. Methods with value receivers like these never change the Vertex they're called on;
+ * each one returns a new value, so they can be chained: v.Add(w).Scale(2).
+ */
+
+// Add returns v + w.
+func (v Vertex) Add(w Vertex) Vertex {
+	return Vertex{X: v.X + w.X, Y: v.Y + w.Y}
+}
+
+// Sub returns v - w.
+func (v Vertex) Sub(w Vertex) Vertex {
+	return Vertex{X: v.X - w.X, Y: v.Y - w.Y}
+}
+
+// Scale returns v multiplied by k.
+func (v Vertex) Scale(k int) Vertex {
+	return Vertex{X: k * v.X, Y: k * v.Y}
+}
+
+// Neg returns -v.
+func (v Vertex) Neg() Vertex {
+	return Vertex{X: -v.X, Y: -v.Y}
+}
+
+// Dot returns the dot product v·w.
+func (v Vertex) Dot(w Vertex) int {
+	return v.X*w.X + v.Y*w.Y
+}
+
+// Cross returns the z component of the cross product v×w: positive if w is counterclockwise from v,
+// negative if clockwise, and 0 if they are parallel.
+func (v Vertex) Cross(w Vertex) int {
+	return v.X*w.Y - v.Y*w.X
+}
+
+// Length returns the Euclidean length of v, the hypot of its coordinates.
+func (v Vertex) Length() float64 {
+	return math.Hypot(float64(v.X), float64(v.Y))
+}
+
+// Normalize returns the unit vector in v's direction. The zero Vertex has no direction, so it
+// normalizes to the zero Vec.
+func (v Vertex) Normalize() Vec {
+	return v.Vec().Normalize()
+}
+
+// Rotate returns v rotated counterclockwise by theta radians. The result is generally not on the
+// integer grid, so it is a Vec.
+func (v Vertex) Rotate(theta float64) Vec {
+	return v.Vec().Rotate(theta)
+}
+
+// Rotate90 returns v rotated a quarter turn counterclockwise, exactly.
+func (v Vertex) Rotate90() Vertex {
+	return Vertex{X: -v.Y, Y: v.X}
+}
+
+// Equal reports whether v and w are the same point. It is the same as v == w.
+func (v Vertex) Equal(w Vertex) bool {
+	return v == w
+}
+
+// Vec returns v as a Vec.
+func (v Vertex) Vec() Vec {
+	return Vec{X: float64(v.X), Y: float64(v.Y)}
+}
+
+// Vec is Vertex's floating-point counterpart, a point or vector in the plane.
+type Vec struct {
+	X float64
+	Y float64
+}
+
+// Add returns v + w.
+func (v Vec) Add(w Vec) Vec {
+	return Vec{X: v.X + w.X, Y: v.Y + w.Y}
+}
+
+// Sub returns v - w.
+func (v Vec) Sub(w Vec) Vec {
+	return Vec{X: v.X - w.X, Y: v.Y - w.Y}
+}
+
+// Scale returns v multiplied by k.
+func (v Vec) Scale(k float64) Vec {
+	return Vec{X: k * v.X, Y: k * v.Y}
+}
+
+// Neg returns -v.
+func (v Vec) Neg() Vec {
+	return Vec{X: -v.X, Y: -v.Y}
+}
+
+// Dot returns the dot product v·w.
+func (v Vec) Dot(w Vec) float64 {
+	return v.X*w.X + v.Y*w.Y
+}
+
+// Cross returns the z component of the cross product v×w, as Vertex.Cross does.
+func (v Vec) Cross(w Vec) float64 {
+	return v.X*w.Y - v.Y*w.X
+}
+
+// Length returns the Euclidean length of v.
+func (v Vec) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Normalize returns the unit vector in v's direction, or the zero Vec if v is zero.
+func (v Vec) Normalize() Vec {
+	l := v.Length()
+	if l == 0 {
+		return Vec{}
+	}
+
+	return Vec{X: v.X / l, Y: v.Y / l}
+}
+
+// Rotate returns v rotated counterclockwise by theta radians.
+func (v Vec) Rotate(theta float64) Vec {
+	sin, cos := math.Sincos(theta)
+	return Vec{X: v.X*cos - v.Y*sin, Y: v.X*sin + v.Y*cos}
+}
+
+// Angle returns the angle of v counterclockwise from the positive X axis, in radians in (-π, π].
+func (v Vec) Angle() float64 {
+	return math.Atan2(v.Y, v.X)
+}
+
+// Equal reports whether v and w are exactly the same point. Results of floating-point arithmetic
+// rarely are; use ApproxEqual to compare them.
+func (v Vec) Equal(w Vec) bool {
+	return v == w
+}
+
+// ApproxEqual reports whether v and w are no further than eps apart.
+func (v Vec) ApproxEqual(w Vec, eps float64) bool {
+	return v.Sub(w).Length() <= eps
+}
+
+// Round returns the Vertex nearest to v, rounding each coordinate half away from zero.
+func (v Vec) Round() Vertex {
+	return Vertex{X: int(math.Round(v.X)), Y: int(math.Round(v.Y))}
+}
@@ -0,0 +1,104 @@
+package moretypes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVertexAlgebra(t *testing.T) {
+	v, w := Vertex{X: 3, Y: 4}, Vertex{X: -1, Y: 2}
+
+	vertexTests := []struct {
+		name      string
+		got, want Vertex
+	}{
+		{"Add", v.Add(w), Vertex{X: 2, Y: 6}},
+		{"Sub", v.Sub(w), Vertex{X: 4, Y: 2}},
+		{"Scale", v.Scale(-2), Vertex{X: -6, Y: -8}},
+		{"Neg", v.Neg(), Vertex{X: -3, Y: -4}},
+		{"Rotate90", v.Rotate90(), Vertex{X: -4, Y: 3}},
+		{"Rotate90 four times", v.Rotate90().Rotate90().Rotate90().Rotate90(), v},
+		{"chained", v.Add(w).Scale(2).Sub(v), Vertex{X: 1, Y: 8}},
+		{"Round of Vec", v.Vec().Round(), v},
+	}
+	for _, tt := range vertexTests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	intTests := []struct {
+		name      string
+		got, want int
+	}{
+		{"Dot", v.Dot(w), 5},
+		{"Dot perpendicular", v.Dot(v.Rotate90()), 0},
+		{"Cross counterclockwise", v.Cross(w), 10},
+		{"Cross clockwise", w.Cross(v), -10},
+		{"Cross parallel", v.Cross(v.Scale(3)), 0},
+	}
+	for _, tt := range intTests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if got := v.Length(); got != 5 {
+		t.Errorf("Length = %g, want 5", got)
+	}
+	if !v.Equal(Vertex{X: 3, Y: 4}) || v.Equal(w) {
+		t.Error("Equal doesn't match ==")
+	}
+	// v is left alone by its methods.
+	if v != (Vertex{X: 3, Y: 4}) {
+		t.Errorf("v changed to %+v", v)
+	}
+}
+
+func TestVecAlgebra(t *testing.T) {
+	const eps = 1e-12
+	v := Vec{X: 3, Y: 4}
+
+	tests := []struct {
+		name      string
+		got, want Vec
+	}{
+		{"Add", v.Add(Vec{X: 0.5, Y: -1}), Vec{X: 3.5, Y: 3}},
+		{"Sub", v.Sub(Vec{X: 0.5, Y: -1}), Vec{X: 2.5, Y: 5}},
+		{"Scale", v.Scale(0.5), Vec{X: 1.5, Y: 2}},
+		{"Neg", v.Neg(), Vec{X: -3, Y: -4}},
+		{"Normalize", v.Normalize(), Vec{X: 0.6, Y: 0.8}},
+		{"Normalize zero", Vec{}.Normalize(), Vec{}},
+		{"Rotate quarter", v.Rotate(math.Pi / 2), Vec{X: -4, Y: 3}},
+		{"Rotate half", v.Rotate(math.Pi), Vec{X: -3, Y: -4}},
+		{"Rotate back", v.Rotate(1).Rotate(-1), v},
+		{"Vertex Rotate", Vertex{X: 1}.Rotate(math.Pi / 4), Vec{X: math.Sqrt2 / 2, Y: math.Sqrt2 / 2}},
+		{"Vertex Normalize", Vertex{Y: -7}.Normalize(), Vec{Y: -1}},
+	}
+	for _, tt := range tests {
+		if !tt.got.ApproxEqual(tt.want, eps) {
+			t.Errorf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if got := v.Dot(Vec{X: -4, Y: 3}); got != 0 {
+		t.Errorf("Dot of perpendicular vectors = %g", got)
+	}
+	if got := v.Cross(Vec{X: -4, Y: 3}); got != 25 {
+		t.Errorf("Cross = %g, want 25", got)
+	}
+	if got := v.Rotate(0.3).Length(); math.Abs(got-5) > eps {
+		t.Errorf("rotation changed the length to %g", got)
+	}
+	if got := (Vec{X: -1}).Angle(); got != math.Pi {
+		t.Errorf("Angle of -X = %g, want π", got)
+	}
+	if got := (Vec{X: 2.5, Y: -2.5}).Round(); got != (Vertex{X: 3, Y: -3}) {
+		t.Errorf("Round = %+v, want {3 -3}", got)
+	}
+	// 0.1 + 0.2 is 0.30000000000000004 in float64 arithmetic (constants would be exact).
+	a, b := 0.1, 0.2
+	if (Vec{X: a + b}).Equal(Vec{X: 0.3}) || !(Vec{X: a + b}).ApproxEqual(Vec{X: 0.3}, eps) {
+		t.Error("Equal should be exact and ApproxEqual approximate")
+	}
+}
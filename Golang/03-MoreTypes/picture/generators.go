@@ -0,0 +1,139 @@
+package picture
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+// Generator is a named pixel function for moretypes.PicFunc.
+type Generator struct {
+	Name        string
+	Description string
+	Func        moretypes.PixelFunc
+}
+
+// DefaultGenerator is the name of Pic's own pixel function.
+const DefaultGenerator = "squares"
+
+// generators is the catalog of registered generators, by name.
+var generators = map[string]Generator{}
+
+func init() {
+	for _, g := range []Generator{
+		{DefaultGenerator, "x*x + y*y (Pic's own function)", moretypes.SumOfSquares},
+		{"average", "(x+y)/2", func(x, y, dx, dy int) int { return (x + y) / 2 }},
+		{"product", "x*y", func(x, y, dx, dy int) int { return x * y }},
+		{"xor", "x^y", func(x, y, dx, dy int) int { return x ^ y }},
+		{"hgradient", "left-to-right gradient from 0 to 255", hgradient},
+		{"vgradient", "top-to-bottom gradient from 0 to 255", vgradient},
+		{"radial", "gradient from 0 at the center to 255 at the corners", radial},
+		{"checkerboard", "8x8 board of alternating 0 and 255 squares", checkerboard},
+		{"mandelbrot", "escape-time Mandelbrot set (0 inside the set)", mandelbrot},
+	} {
+		if err := Register(g); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds g to the catalog so Lookup can find it by name. It returns an error if g has no name or
+// function, or if a generator with the same name is already registered. Register isn't safe to call
+// concurrently with Lookup; call it during program initialization.
+func Register(g Generator) error {
+	switch {
+	case g.Name == "":
+		return fmt.Errorf("picture: generator has no name")
+	case g.Func == nil:
+		return fmt.Errorf("picture: generator %q has no function", g.Name)
+	}
+
+	if _, dup := generators[g.Name]; dup {
+		return fmt.Errorf("picture: generator %q is already registered", g.Name)
+	}
+	generators[g.Name] = g
+
+	return nil
+}
+
+// Lookup returns the pixel function of the generator called name.
+func Lookup(name string) (moretypes.PixelFunc, error) {
+	g, ok := generators[name]
+	if !ok {
+		return nil, fmt.Errorf("picture: unknown generator %q", name)
+	}
+
+	return g.Func, nil
+}
+
+// Generators returns every registered generator, sorted by name.
+func Generators() []Generator {
+	list := make([]Generator, 0, len(generators))
+	for _, g := range generators {
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list
+}
+
+// scale maps v in [0, n-1] onto [0, 255].
+func scale(v, n int) int {
+	if n <= 1 {
+		return 0
+	}
+
+	return v * 255 / (n - 1)
+}
+
+func hgradient(x, y, dx, dy int) int {
+	return scale(x, dx)
+}
+
+func vgradient(x, y, dx, dy int) int {
+	return scale(y, dy)
+}
+
+func radial(x, y, dx, dy int) int {
+	// distances from the center, in half pixels so the center of an even-sized picture falls between pixels.
+	cx, cy := float64(2*x-(dx-1)), float64(2*y-(dy-1))
+	corner := math.Hypot(float64(dx-1), float64(dy-1))
+	if corner == 0 {
+		return 0
+	}
+
+	return int(255 * math.Hypot(cx, cy) / corner)
+}
+
+func checkerboard(x, y, dx, dy int) int {
+	// 8 squares across and down, each at least one pixel.
+	w, h := max(dx/8, 1), max(dy/8, 1)
+	if (x/w+y/h)%2 == 0 {
+		return 255
+	}
+
+	return 0
+}
+
+// mandelbrotIterations is the escape-time limit; it matches the 0-255 pixel range.
+const mandelbrotIterations = 255
+
+func mandelbrot(x, y, dx, dy int) int {
+	// map the picture onto the region [-2.5, 1] x [-1.25, 1.25] of the complex plane.
+	c := complex(
+		-2.5+3.5*float64(x)/float64(max(dx-1, 1)),
+		-1.25+2.5*float64(y)/float64(max(dy-1, 1)),
+	)
+
+	z := complex(0, 0)
+	for i := 0; i < mandelbrotIterations; i++ {
+		z = z*z + c
+		if real(z)*real(z)+imag(z)*imag(z) > 4 {
+			return i + 1
+		}
+	}
+
+	return 0
+}
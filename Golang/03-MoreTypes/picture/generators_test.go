@@ -0,0 +1,83 @@
+package picture
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+)
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		name string
+		dx   int
+		dy   int
+		want [][]uint8
+	}{
+		{"squares", 3, 2, [][]uint8{{0, 1, 4}, {1, 2, 5}}},
+		{"average", 3, 2, [][]uint8{{0, 0, 1}, {0, 1, 1}}},
+		{"product", 3, 3, [][]uint8{{0, 0, 0}, {0, 1, 2}, {0, 2, 4}}},
+		{"xor", 3, 2, [][]uint8{{0, 1, 2}, {1, 0, 3}}},
+		{"hgradient", 3, 2, [][]uint8{{0, 127, 255}, {0, 127, 255}}},
+		{"vgradient", 2, 3, [][]uint8{{0, 0}, {127, 127}, {255, 255}}},
+		{"radial", 3, 3, [][]uint8{{255, 180, 255}, {180, 0, 180}, {255, 180, 255}}},
+		{"checkerboard", 2, 2, [][]uint8{{255, 0}, {0, 255}}},
+	}
+
+	for _, tt := range tests {
+		fn, err := Lookup(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := moretypes.PicFunc(tt.dx, tt.dy, fn); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMandelbrot(t *testing.T) {
+	fn, err := Lookup("mandelbrot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// in a 8x5 picture, (5, 2) maps to c = 0, which is in the set, and (0, 0) to c = -2.5-1.25i, which
+	// escapes on the first iteration.
+	if got := fn(5, 2, 8, 5); got != 0 {
+		t.Errorf("mandelbrot at c = 0 = %d, want 0", got)
+	}
+	if got := fn(0, 0, 8, 5); got != 1 {
+		t.Errorf("mandelbrot at c = -2.5-1.25i = %d, want 1", got)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	identity := Generator{Name: "test-diagonal", Description: "x == y", Func: func(x, y, dx, dy int) int {
+		if x == y {
+			return 255
+		}
+		return 0
+	}}
+
+	if err := Register(identity); err != nil {
+		t.Fatal(err)
+	}
+	if err := Register(identity); err == nil {
+		t.Error("registering a duplicate name succeeded, want error")
+	}
+	if err := Register(Generator{Name: "no-func"}); err == nil {
+		t.Error("registering a generator without a function succeeded, want error")
+	}
+
+	fn, err := Lookup("test-diagonal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := moretypes.PicFunc(2, 2, fn), [][]uint8{{255, 0}, {0, 255}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("registered generator: got %v, want %v", got, want)
+	}
+
+	if _, err := Lookup("no-such-generator"); err == nil {
+		t.Error("Lookup of an unknown name succeeded, want error")
+	}
+}
@@ -0,0 +1,154 @@
+// Package picture turns the [][]uint8 pictures built by moretypes.Pic into real images.
+//
+// A picture is a slice of dy rows of dx pixel values. It can be encoded as a PNG, in either the Go tour's
+// "bluescale" palette or plain grayscale, or as a binary PGM (portable graymap), which is always grayscale.
+//
+// The package also keeps a catalog of named pixel functions (Generators) for moretypes.PicFunc, from the
+// exercise's suggestions (x^y, x*y, ...) to gradients, checkerboards and the Mandelbrot set.
+package picture
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// Palette maps a pixel value to a color.
+type Palette string
+
+const (
+	Gray Palette = "gray" // value v is the gray level v
+	Blue Palette = "blue" // value v is color.RGBA{v, v, 255, 255}, like the Go tour's pic.Show
+)
+
+// String returns the name of the palette.
+func (p *Palette) String() string {
+	if p == nil || *p == "" {
+		return string(Blue)
+	}
+
+	return string(*p)
+}
+
+// Set parses s as a Palette, so a Palette can be bound to a flag.
+func (p *Palette) Set(s string) error {
+	switch Palette(s) {
+	case Gray, Blue:
+		*p = Palette(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown palette %q (want %s or %s)", s, Gray, Blue)
+	}
+}
+
+// Encoding is an image file format.
+type Encoding string
+
+const (
+	PNG Encoding = "png"
+	PGM Encoding = "pgm" // binary ("P5") portable graymap
+)
+
+// String returns the name of the encoding.
+func (e *Encoding) String() string {
+	if e == nil || *e == "" {
+		return string(PNG)
+	}
+
+	return string(*e)
+}
+
+// Set parses s as an Encoding, so an Encoding can be bound to a flag.
+func (e *Encoding) Set(s string) error {
+	switch Encoding(s) {
+	case PNG, PGM:
+		*e = Encoding(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown encoding %q (want %s or %s)", s, PNG, PGM)
+	}
+}
+
+// ErrEmpty is returned when asked to encode a picture with no pixels.
+var ErrEmpty = errors.New("picture: picture has no pixels")
+
+// Bounds returns the width and height of pic. The width is the length of the longest row;
+// shorter rows are padded with zeros when the picture is converted or encoded.
+func Bounds(pic [][]uint8) (dx, dy int) {
+	for _, row := range pic {
+		dx = max(dx, len(row))
+	}
+
+	return dx, len(pic)
+}
+
+// Image converts pic to an image.Image using palette p (an empty Palette means Blue).
+func Image(pic [][]uint8, p Palette) image.Image {
+	dx, dy := Bounds(pic)
+	rect := image.Rect(0, 0, dx, dy)
+
+	if p == Gray {
+		img := image.NewGray(rect)
+		for y, row := range pic {
+			copy(img.Pix[y*img.Stride:], row)
+		}
+		return img
+	}
+
+	img := image.NewRGBA(rect)
+	for y := 0; y < dy; y++ {
+		for x := 0; x < dx; x++ {
+			var v uint8
+			if x < len(pic[y]) {
+				v = pic[y][x]
+			}
+			img.SetRGBA(x, y, color.RGBA{R: v, G: v, B: 255, A: 255})
+		}
+	}
+
+	return img
+}
+
+// Encode writes pic to w in encoding e (an empty Encoding means PNG). The palette only applies to PNG.
+func Encode(w io.Writer, pic [][]uint8, e Encoding, p Palette) error {
+	switch e {
+	case PNG, "":
+		return EncodePNG(w, pic, p)
+	case PGM:
+		return EncodePGM(w, pic)
+	default:
+		return fmt.Errorf("picture: unknown encoding %q", string(e))
+	}
+}
+
+// EncodePNG writes pic to w as a PNG image in palette p.
+func EncodePNG(w io.Writer, pic [][]uint8, p Palette) error {
+	if dx, dy := Bounds(pic); dx == 0 || dy == 0 {
+		return ErrEmpty
+	}
+
+	return png.Encode(w, Image(pic, p))
+}
+
+// EncodePGM writes pic to w as a binary (P5) PGM image with a maximum gray value of 255.
+func EncodePGM(w io.Writer, pic [][]uint8) error {
+	dx, dy := Bounds(pic)
+	if dx == 0 || dy == 0 {
+		return ErrEmpty
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P5\n%d %d\n255\n", dx, dy)
+
+	pad := make([]byte, dx)
+	for _, row := range pic {
+		bw.Write(row)
+		bw.Write(pad[len(row):])
+	}
+
+	return bw.Flush()
+}
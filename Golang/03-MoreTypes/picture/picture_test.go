@@ -0,0 +1,70 @@
+package picture
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+var sample = [][]uint8{
+	{0, 1, 4},
+	{1, 2, 5},
+}
+
+func TestEncodePNG(t *testing.T) {
+	tests := []struct {
+		palette Palette
+		at      func(v uint8) color.Color
+	}{
+		{Gray, func(v uint8) color.Color { return color.Gray{Y: v} }},
+		{Blue, func(v uint8) color.Color { return color.RGBA{R: v, G: v, B: 255, A: 255} }},
+	}
+
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := EncodePNG(&b, sample, tt.palette); err != nil {
+			t.Fatalf("%s: %v", tt.palette, err)
+		}
+
+		img, err := png.Decode(&b)
+		if err != nil {
+			t.Fatalf("%s: decoding: %v", tt.palette, err)
+		}
+		if got := img.Bounds().Size(); got.X != 3 || got.Y != 2 {
+			t.Errorf("%s: size %v, want 3x2", tt.palette, got)
+		}
+
+		for y, row := range sample {
+			for x, v := range row {
+				gr, gg, gb, ga := img.At(x, y).RGBA()
+				wr, wg, wb, wa := tt.at(v).RGBA()
+				if gr != wr || gg != wg || gb != wb || ga != wa {
+					t.Errorf("%s: pixel (%d, %d) = %v, want %v", tt.palette, x, y, img.At(x, y), tt.at(v))
+				}
+			}
+		}
+	}
+}
+
+func TestEncodePGM(t *testing.T) {
+	var b bytes.Buffer
+	if err := EncodePGM(&b, [][]uint8{{0, 1, 4}, {1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the short second row is padded with zeros.
+	want := append([]byte("P5\n3 2\n255\n"), 0, 1, 4, 1, 0, 0)
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("EncodePGM = %q, want %q", b.Bytes(), want)
+	}
+}
+
+func TestEncodeEmpty(t *testing.T) {
+	for _, e := range []Encoding{PNG, PGM} {
+		if err := Encode(&bytes.Buffer{}, [][]uint8{{}, {}}, e, Gray); !errors.Is(err, ErrEmpty) {
+			t.Errorf("Encode(%s) of an empty picture = %v, want ErrEmpty", e, err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package tictactoe
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Level is how well a Computer plays.
+type Level string
+
+const (
+	Random  Level = "random"  // any empty square
+	Greedy  Level = "greedy"  // win if it can, block if it must, otherwise any empty square
+	Perfect Level = "perfect" // minimax with alpha-beta pruning; never loses on a 3x3 board
+)
+
+// String returns the name of the level.
+func (l *Level) String() string {
+	if l == nil || *l == "" {
+		return string(Perfect)
+	}
+
+	return string(*l)
+}
+
+// Set parses s as a Level, so a Level can be bound to a flag.
+func (l *Level) Set(s string) error {
+	switch Level(s) {
+	case Random, Greedy, Perfect:
+		*l = Level(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown level %q (want %s, %s or %s)", s, Random, Greedy, Perfect)
+	}
+}
+
+// Computer chooses moves for whichever player is to move. The zero value plays perfectly.
+type Computer struct {
+	Level Level
+
+	// Depth is how many moves ahead Perfect searches. Zero means to the end of the game on boards of
+	// Size or smaller and DefaultDepth on larger ones, where a full search would take far too long.
+	// Searches that stop early score unfinished games as draws, so Perfect only plays perfectly on
+	// boards it can search to the end.
+	Depth int
+
+	// Rand picks among equally good squares for the Random and Greedy levels. If nil, the
+	// math/rand/v2 top-level functions are used. Perfect play is deterministic.
+	Rand *rand.Rand
+}
+
+// Move returns the square the computer would play on b. It doesn't change b.
+func (c Computer) Move(b *Board) (Move, error) {
+	if b.Status() != InProgress {
+		return Move{}, ErrGameOver
+	}
+
+	switch c.Level {
+	case Random:
+		return c.pick(b.Empties()), nil
+	case Greedy:
+		return c.greedy(b), nil
+	case Perfect, "":
+		return perfect(b, c.depth(b)), nil
+	default:
+		return Move{}, fmt.Errorf("tictactoe: unknown level %q", string(c.Level))
+	}
+}
+
+// DefaultDepth is how many moves ahead Perfect searches on boards larger than Size.
+const DefaultDepth = 4
+
+// depth returns how many moves ahead Perfect should search on b.
+func (c Computer) depth(b *Board) int {
+	switch {
+	case c.Depth > 0:
+		return c.Depth
+	case b.config.Size <= Size:
+		return b.config.Size * b.config.Size
+	default:
+		return DefaultDepth
+	}
+}
+
+// pick returns one of moves at random.
+func (c Computer) pick(moves []Move) Move {
+	if c.Rand != nil {
+		return moves[c.Rand.IntN(len(moves))]
+	}
+
+	return moves[rand.IntN(len(moves))]
+}
+
+// greedy returns a winning move if there is one, otherwise a move that stops the opponent winning on
+// their next turn, otherwise a random move. It looks no further ahead than that.
+func (c Computer) greedy(b *Board) Move {
+	moves := b.Empties()
+	if m, ok := winningMove(b, moves); ok {
+		return m
+	}
+
+	// block the first square the opponent would win on.
+	for _, m := range moves {
+		if b.wins(m.Row, m.Col, b.opponent()) {
+			return m
+		}
+	}
+
+	return c.pick(moves)
+}
+
+// winningMove returns the first of moves that wins the game for the player to move.
+func winningMove(b *Board, moves []Move) (Move, bool) {
+	for _, m := range moves {
+		if b.wins(m.Row, m.Col, m.Player) {
+			return m, true
+		}
+	}
+
+	return Move{}, false
+}
+
+// perfect returns the best move for the player to move, looking depth moves ahead: the quickest win,
+// otherwise a draw, otherwise the slowest loss. Ties go to the first candidate square in row-major order.
+func perfect(b *Board, depth int) Move {
+	var best Move
+	alpha, beta := -score(b, 0), score(b, 0)
+	for _, m := range candidates(b) {
+		b.Play(m.Row, m.Col)
+		v := -negamax(b, depth-1, -beta, -alpha)
+		b.Undo()
+		if v > alpha || best.Player == "" {
+			best, alpha = m, max(alpha, v)
+		}
+	}
+
+	return best
+}
+
+// negamax returns the value of b for the player to move, searching depth moves ahead with alpha-beta
+// pruning. A win is worth more the fewer moves it takes; a draw, or a game still going at the end of
+// the search, is worth 0.
+func negamax(b *Board, depth, alpha, beta int) int {
+	switch {
+	case b.Status() == Won:
+		// the previous move won, so the player to move has lost.
+		return -score(b, len(b.history))
+	case b.Status() == Draw, depth == 0:
+		return 0
+	}
+
+	for _, m := range candidates(b) {
+		b.Play(m.Row, m.Col)
+		v := -negamax(b, depth-1, -beta, -alpha)
+		b.Undo()
+		if v >= beta {
+			return v
+		}
+		alpha = max(alpha, v)
+	}
+
+	return alpha
+}
+
+// score is the value of a win after moves moves on b; earlier wins score higher, and every win beats a
+// draw.
+func score(b *Board, moves int) int {
+	return b.config.Size*b.config.Size + 1 - moves
+}
+
+// candidates returns the moves worth searching on b. On boards of Size or smaller that is every empty
+// square. On larger boards it is the empty squares next to a marker (or the center of an empty board),
+// which keeps the search narrow without missing any immediate win or block.
+func candidates(b *Board) []Move {
+	n := b.config.Size
+	if n <= Size {
+		return b.Empties()
+	}
+	if len(b.history) == 0 {
+		return []Move{{Player: b.Turn(), Row: n / 2, Col: n / 2}}
+	}
+
+	var moves []Move
+	for _, m := range b.Empties() {
+		if b.nextToMarker(m.Row, m.Col) {
+			moves = append(moves, m)
+		}
+	}
+
+	return moves
+}
+
+// nextToMarker reports whether any of the eight squares around (row, col) holds a player's marker.
+func (b *Board) nextToMarker(row, col int) bool {
+	for r := max(row-1, 0); r <= min(row+1, b.config.Size-1); r++ {
+		for c := max(col-1, 0); c <= min(col+1, b.config.Size-1); c++ {
+			if (r != row || c != col) && b.cells[r][c] != b.config.Empty {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,210 @@
+package tictactoe
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// neverLoses plays c against every possible sequence of opponent moves from b and fails the test if the
+// opponent ever wins. It returns the number of games played.
+func neverLoses(t *testing.T, b *Board, c Computer, computer string) int {
+	t.Helper()
+
+	if b.Status() != InProgress {
+		if w := b.Winner(); w != "" && w != computer {
+			t.Fatalf("computer playing %s lost:\n%s", computer, b)
+		}
+		return 1
+	}
+
+	if b.Turn() == computer {
+		m, err := c.Move(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Play(m.Row, m.Col); err != nil {
+			t.Fatalf("computer chose an illegal move %+v: %v\n%s", m, err, b)
+		}
+		n := neverLoses(t, b, c, computer)
+		b.Undo()
+		return n
+	}
+
+	games := 0
+	for _, m := range b.Empties() {
+		b.Play(m.Row, m.Col)
+		games += neverLoses(t, b, c, computer)
+		b.Undo()
+	}
+
+	return games
+}
+
+func TestPerfectNeverLoses(t *testing.T) {
+	for _, computer := range []string{X, O} {
+		if games := neverLoses(t, NewBoard(), Computer{Level: Perfect}, computer); games == 0 {
+			t.Errorf("computer playing %s: no games played", computer)
+		}
+	}
+}
+
+func TestPerfectAgainstItselfDraws(t *testing.T) {
+	b := NewBoard()
+	for b.Status() == InProgress {
+		m, err := Computer{}.Move(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Play(m.Row, m.Col)
+	}
+
+	if b.Status() != Draw {
+		t.Errorf("perfect play against itself ended %v:\n%s", b.Status(), b)
+	}
+}
+
+func TestComputerTakesWinAndBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		squares []int
+		want    Move
+	}{
+		// X: 0 1, O: 3 4. X to move wins at 2 rather than blocking at 5.
+		{"win", []int{0, 3, 1, 4}, Move{Player: X, Row: 0, Col: 2}},
+		// X: 0 1, O: 4. O to move must block at 2.
+		{"block", []int{0, 4, 1}, Move{Player: O, Row: 0, Col: 2}},
+		// X: 2 6, O: 4. The perfect reply is an edge; the corner at 0 loses to a fork.
+		{"avoid fork", []int{2, 4, 6}, Move{Player: O, Row: 0, Col: 1}},
+	}
+
+	for _, tt := range tests {
+		for _, level := range []Level{Greedy, Perfect} {
+			if level == Greedy && tt.name == "avoid fork" {
+				continue
+			}
+
+			b := play(t, tt.squares...)
+			before := b.String()
+			got, err := Computer{Level: level, Rand: rand.New(rand.NewPCG(1, 2))}.Move(b)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", tt.name, level, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s/%s: Move() = %+v, want %+v\n%s", tt.name, level, got, tt.want, b)
+			}
+			if b.String() != before || len(b.History()) != len(tt.squares) {
+				t.Errorf("%s/%s: Move() changed the board", tt.name, level)
+			}
+		}
+	}
+}
+
+func TestRandomPlaysLegalMoves(t *testing.T) {
+	c := Computer{Level: Random, Rand: rand.New(rand.NewPCG(1, 2))}
+	for game := 0; game < 20; game++ {
+		b := NewBoard()
+		for b.Status() == InProgress {
+			m, err := c.Move(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Play(m.Row, m.Col); err != nil {
+				t.Fatalf("Random chose %+v: %v", m, err)
+			}
+		}
+	}
+
+	if _, err := c.Move(play(t, 0, 3, 1, 4, 2)); err != ErrGameOver {
+		t.Errorf("Move on a finished game = %v, want ErrGameOver", err)
+	}
+}
+
+func TestLevelSet(t *testing.T) {
+	var l Level
+	if got := l.String(); got != string(Perfect) {
+		t.Errorf("zero Level = %q, want %q", got, Perfect)
+	}
+	if err := l.Set("greedy"); err != nil || l != Greedy {
+		t.Errorf("Set(greedy) = %v, level %q", err, l)
+	}
+	if err := l.Set("cheating"); err == nil {
+		t.Error("Set(cheating) succeeded")
+	}
+}
+
+func TestPlayComputer(t *testing.T) {
+	// the person plays X in a corner, takes it back along with the computer's reply, then plays the center.
+	var out strings.Builder
+	b, err := PlayComputer(strings.NewReader("1 1\nundo\n2 2\nquit\n"), &out, Computer{}, O)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"O plays", "Took back O at", "Took back X at 1 1."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output doesn't mention %q:\n%s", want, out.String())
+		}
+	}
+	if got := b.Cell(1, 1); got != X {
+		t.Errorf("center square = %q after the person played it, want X", got)
+	}
+	if len(b.History()) != 2 {
+		t.Errorf("history %v, want the person's move and the computer's reply", b.History())
+	}
+
+	if _, err := PlayComputer(strings.NewReader(""), &out, Computer{}, "Z"); err == nil {
+		t.Error("PlayComputer with marker Z succeeded")
+	}
+}
+
+func TestComputerLargeBoards(t *testing.T) {
+	gomoku := Config{Size: 15, WinLength: 5, Empty: ".", Players: [2]string{"B", "W"}}
+
+	// B has four in a row on row 7 from (7,4) to (7,7), blocked by W at (7,3); W must block at (7,8).
+	b := playConfig(t, gomoku, 109, 108, 110, 0, 111, 2, 112)
+	for _, level := range []Level{Greedy, Perfect} {
+		m, err := Computer{Level: level}.Move(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Row != 7 || m.Col != 8 {
+			t.Errorf("%s: W played %d %d, want the block at 7 8", level, m.Row, m.Col)
+		}
+	}
+
+	// both players have four in a row; B is to move and takes the win instead of blocking W at (2,4).
+	b = playConfig(t, gomoku, 109, 30, 110, 31, 111, 32, 112, 33)
+	for _, level := range []Level{Greedy, Perfect} {
+		m, err := Computer{Level: level}.Move(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Row != 7 || (m.Col != 3 && m.Col != 8) {
+			t.Errorf("%s: B played %d %d, want the win at 7 3 or 7 8", level, m.Row, m.Col)
+		}
+	}
+
+	// the computer opens in the center of an empty board.
+	empty := playConfig(t, gomoku)
+	if m, _ := (Computer{}).Move(empty); m.Row != 7 || m.Col != 7 {
+		t.Errorf("opening move %d %d, want the center", m.Row, m.Col)
+	}
+}
+
+func TestPerfectFourByFour(t *testing.T) {
+	c := Config{Size: 4, WinLength: 4, Empty: Empty, Players: [2]string{X, O}}
+	b := playConfig(t, c)
+	for b.Status() == InProgress {
+		m, err := Computer{}.Move(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Play(m.Row, m.Col)
+	}
+
+	// neither side can force four in a row on a 4x4 board.
+	if b.Status() != Draw {
+		t.Errorf("depth-limited play against itself ended %v:\n%s", b.Status(), b)
+	}
+}
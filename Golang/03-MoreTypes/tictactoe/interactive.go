@@ -0,0 +1,142 @@
+package tictactoe
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Interactive plays a game between two people sharing a terminal. Each turn it prints the board and
+// reads one command from in:
+//
+//	ROW COL  place the current player's marker (1-based, e.g. "2 3")
+//	undo     take back the last move
+//	quit     stop the game
+//
+// Invalid input is reported and the same player is asked again. Interactive returns the final board when
+// the game is won, drawn or quit, and io.ErrUnexpectedEOF if in runs out before that.
+func Interactive(in io.Reader, out io.Writer) (*Board, error) {
+	b := NewBoard()
+	return b, b.Interactive(in, out)
+}
+
+// PlayComputer is like Interactive, but c plays the marker computer (X or O) and the person at the terminal
+// plays the other one. Undo takes back the person's last move along with the computer's reply.
+func PlayComputer(in io.Reader, out io.Writer, c Computer, computer string) (*Board, error) {
+	b := NewBoard()
+	return b, b.PlayComputer(in, out, c, computer)
+}
+
+// Interactive is like the package's Interactive function, but plays on b, from whatever position b is in.
+func (b *Board) Interactive(in io.Reader, out io.Writer) error {
+	return b.run(in, out, nil, "")
+}
+
+// PlayComputer is like the package's PlayComputer function, but plays on b, from whatever position b is in.
+// computer must be one of b's player markers.
+func (b *Board) PlayComputer(in io.Reader, out io.Writer, c Computer, computer string) error {
+	if p := b.config.Players; computer != p[0] && computer != p[1] {
+		return fmt.Errorf("tictactoe: computer must play %s or %s, not %q", p[0], p[1], computer)
+	}
+
+	return b.run(in, out, &c, computer)
+}
+
+// run runs the game loop for Interactive and PlayComputer. If c is nil, both players are people.
+func (b *Board) run(in io.Reader, out io.Writer, c *Computer, computer string) error {
+	scanner := bufio.NewScanner(in)
+
+	for b.Status() == InProgress {
+		if c != nil && b.Turn() == computer {
+			m, err := c.Move(b)
+			if err != nil {
+				return err
+			}
+			b.Play(m.Row, m.Col)
+			fmt.Fprintf(out, "\n%s plays %d %d.\n", m.Player, m.Row+1, m.Col+1)
+			continue
+		}
+
+		fmt.Fprintf(out, "\n%s%s to move (row col, undo, quit): ", b, b.Turn())
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			fmt.Fprintln(out)
+			return io.ErrUnexpectedEOF
+		}
+
+		switch cmd := strings.TrimSpace(scanner.Text()); cmd {
+		case "quit", "q":
+			fmt.Fprintln(out, "Game abandoned.")
+			return nil
+		case "undo", "u":
+			// against the computer, the last move is its reply, so take back one more.
+			n := 1
+			if c != nil {
+				n = 2
+			}
+			if len(b.history) < n {
+				fmt.Fprintln(out, "Nothing to undo.")
+				break
+			}
+			for i := 0; i < n; i++ {
+				m, _ := b.Undo()
+				fmt.Fprintf(out, "Took back %s at %d %d.\n", m.Player, m.Row+1, m.Col+1)
+			}
+		default:
+			row, col, err := parseSquare(cmd)
+			if err == nil {
+				err = b.Play(row, col)
+			}
+			if err != nil {
+				fmt.Fprintln(out, b.describe(err))
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "\n%s", b)
+	if b.Status() == Draw {
+		fmt.Fprintln(out, "It's a draw.")
+	} else {
+		fmt.Fprintf(out, "%s wins!\n", b.Winner())
+	}
+
+	return nil
+}
+
+// errSyntax reports input that isn't two numbers.
+var errSyntax = errors.New(`enter a row and a column, like "2 3"`)
+
+// parseSquare parses "ROW COL" (1-based) into 0-based coordinates.
+func parseSquare(s string) (row, col int, err error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return 0, 0, errSyntax
+	}
+
+	if row, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, errSyntax
+	}
+	if col, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, errSyntax
+	}
+
+	return row - 1, col - 1, nil
+}
+
+// describe turns a move error into a message for the player.
+func (b *Board) describe(err error) string {
+	switch {
+	case errors.Is(err, ErrOutOfBounds):
+		return fmt.Sprintf("Rows and columns go from 1 to %d.", b.config.Size)
+	case errors.Is(err, ErrOccupied):
+		return "That square is taken."
+	default:
+		s := err.Error()
+		return strings.ToUpper(s[:1]) + s[1:] + "."
+	}
+}
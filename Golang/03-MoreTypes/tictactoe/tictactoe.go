@@ -0,0 +1,284 @@
+// Package tictactoe is a tic-tac-toe engine built around the [][]string board from the "More Types" lesson.
+//
+// A Board tracks whose turn it is, validates moves, detects wins and draws, and keeps a move history so
+// moves can be undone. A Computer chooses moves at one of three levels, up to perfect play. Interactive
+// plays a game between two people in a terminal, and PlayComputer pits a person against a Computer.
+//
+// NewBoard sets up the lesson's game: 3x3, three in a row, "X" and "O" on a board of "_". NewBoardConfig
+// sets up any N×N board with any win length and markers, from 4x4 four-in-a-row to 15x15 gomoku.
+package tictactoe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Markers used on the default board.
+const (
+	Empty = "_"
+	X     = "X"
+	O     = "O"
+)
+
+// Size is the number of rows and columns on the default board.
+const Size = 3
+
+var (
+	ErrOutOfBounds = errors.New("tictactoe: square is off the board")
+	ErrOccupied    = errors.New("tictactoe: square is already taken")
+	ErrGameOver    = errors.New("tictactoe: game is over")
+	ErrNoHistory   = errors.New("tictactoe: no moves to undo")
+)
+
+// Config describes a board: its size, how many markers in a row win, and the markers themselves.
+type Config struct {
+	Size      int       // rows and columns
+	WinLength int       // markers in a row, column or diagonal needed to win
+	Empty     string    // marker for an empty square
+	Players   [2]string // markers of the first and second player
+}
+
+// DefaultConfig is the lesson's 3x3 game.
+var DefaultConfig = Config{Size: Size, WinLength: Size, Empty: Empty, Players: [2]string{X, O}}
+
+// validate reports whether c describes a playable board.
+func (c Config) validate() error {
+	switch {
+	case c.Size < 1:
+		return fmt.Errorf("tictactoe: board size must be at least 1, got %d", c.Size)
+	case c.WinLength < 1 || c.WinLength > c.Size:
+		return fmt.Errorf("tictactoe: win length must be between 1 and %d, got %d", c.Size, c.WinLength)
+	case c.Empty == "" || c.Players[0] == "" || c.Players[1] == "":
+		return errors.New("tictactoe: markers must not be empty")
+	case c.Players[0] == c.Players[1] || c.Players[0] == c.Empty || c.Players[1] == c.Empty:
+		return fmt.Errorf("tictactoe: markers %q, %q and %q must all differ", c.Empty, c.Players[0], c.Players[1])
+	}
+
+	return nil
+}
+
+// Move is a marker placed on the board. Row and Col are 0-based.
+type Move struct {
+	Player string
+	Row    int
+	Col    int
+}
+
+// Status is the state of a game.
+type Status int
+
+const (
+	InProgress Status = iota
+	Won
+	Draw
+)
+
+// String returns a human-readable name for the status.
+func (s Status) String() string {
+	switch s {
+	case InProgress:
+		return "in progress"
+	case Won:
+		return "won"
+	case Draw:
+		return "draw"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Board is a tic-tac-toe game. The first player always moves first. The zero value is not usable; call
+// NewBoard or NewBoardConfig.
+type Board struct {
+	config  Config
+	cells   [][]string
+	history []Move
+	winner  string // set by the move that wins, cleared when that move is undone
+}
+
+// NewBoard returns an empty board with DefaultConfig and X to move.
+func NewBoard() *Board {
+	b, err := NewBoardConfig(DefaultConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// NewBoardConfig returns an empty board described by c, with c.Players[0] to move.
+func NewBoardConfig(c Config) (*Board, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	cells := make([][]string, c.Size)
+	for i := range cells {
+		cells[i] = make([]string, c.Size)
+		for j := range cells[i] {
+			cells[i][j] = c.Empty
+		}
+	}
+
+	return &Board{config: c, cells: cells}, nil
+}
+
+// Config returns the configuration the board was created with.
+func (b *Board) Config() Config {
+	return b.config
+}
+
+// Turn returns the marker of the player to move next.
+func (b *Board) Turn() string {
+	return b.config.Players[len(b.history)%2]
+}
+
+// opponent returns the marker of the player who isn't to move.
+func (b *Board) opponent() string {
+	return b.config.Players[(len(b.history)+1)%2]
+}
+
+// Cell returns the marker at (row, col), or the empty marker. It panics if the square is off the board.
+func (b *Board) Cell(row, col int) string {
+	return b.cells[row][col]
+}
+
+// Rows returns a copy of the board as a slice of rows.
+func (b *Board) Rows() [][]string {
+	rows := make([][]string, len(b.cells))
+	for i, row := range b.cells {
+		rows[i] = append([]string(nil), row...)
+	}
+
+	return rows
+}
+
+// History returns the moves played so far, oldest first.
+func (b *Board) History() []Move {
+	return append([]Move(nil), b.history...)
+}
+
+// Play places the current player's marker at (row, col) and passes the turn to the other player.
+func (b *Board) Play(row, col int) error {
+	n := b.config.Size
+	switch {
+	case b.Status() != InProgress:
+		return ErrGameOver
+	case row < 0 || row >= n || col < 0 || col >= n:
+		return ErrOutOfBounds
+	case b.cells[row][col] != b.config.Empty:
+		return ErrOccupied
+	}
+
+	m := Move{Player: b.Turn(), Row: row, Col: col}
+	b.cells[row][col] = m.Player
+	b.history = append(b.history, m)
+	if b.wins(row, col, m.Player) {
+		b.winner = m.Player
+	}
+
+	return nil
+}
+
+// Undo takes back the last move and returns it.
+func (b *Board) Undo() (Move, error) {
+	if len(b.history) == 0 {
+		return Move{}, ErrNoHistory
+	}
+
+	m := b.history[len(b.history)-1]
+	b.history = b.history[:len(b.history)-1]
+	b.cells[m.Row][m.Col] = b.config.Empty
+	// the game was still going before this move, so nobody had won.
+	b.winner = ""
+
+	return m, nil
+}
+
+// Empties returns every empty square as a Move for the player to move, in row-major order.
+func (b *Board) Empties() []Move {
+	var moves []Move
+	for r, row := range b.cells {
+		for c, cell := range row {
+			if cell == b.config.Empty {
+				moves = append(moves, Move{Player: b.Turn(), Row: r, Col: c})
+			}
+		}
+	}
+
+	return moves
+}
+
+// directions are the steps along a row, a column and the two diagonals.
+var directions = [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// wins reports whether player has WinLength markers in a line through (row, col), counting (row, col)
+// itself as player's whatever it holds. It only looks at the lines through that square, so checking a
+// move costs O(WinLength) however large the board is.
+func (b *Board) wins(row, col int, player string) bool {
+	for _, d := range directions {
+		n := 1 + b.count(row, col, d[0], d[1], player) + b.count(row, col, -d[0], -d[1], player)
+		if n >= b.config.WinLength {
+			return true
+		}
+	}
+
+	return false
+}
+
+// count returns how many of player's markers follow (row, col) in direction (dr, dc), stopping at the
+// first square that isn't player's or at the winning length.
+func (b *Board) count(row, col, dr, dc int, player string) int {
+	n := 0
+	for r, c := row+dr, col+dc; n < b.config.WinLength && r >= 0 && r < b.config.Size && c >= 0 && c < b.config.Size; r, c = r+dr, c+dc {
+		if b.cells[r][c] != player {
+			break
+		}
+		n++
+	}
+
+	return n
+}
+
+// Winner returns the marker of the player with WinLength in a row, or "" if nobody has won.
+func (b *Board) Winner() string {
+	return b.winner
+}
+
+// Status reports whether the game has been won, drawn, or is still going.
+func (b *Board) Status() Status {
+	switch {
+	case b.winner != "":
+		return Won
+	case len(b.history) == b.config.Size*b.config.Size:
+		return Draw
+	default:
+		return InProgress
+	}
+}
+
+// String renders the board one row per line, with the squares of a row separated by spaces. Markers of
+// different widths are padded so the columns line up.
+func (b *Board) String() string {
+	width := 0
+	for _, m := range []string{b.config.Empty, b.config.Players[0], b.config.Players[1]} {
+		width = max(width, utf8.RuneCountInString(m))
+	}
+
+	var s strings.Builder
+	for i := 0; i < len(b.cells); i++ {
+		line := strings.Join(b.cells[i], " ")
+		if width > 1 {
+			row := make([]string, len(b.cells[i]))
+			for j, cell := range b.cells[i] {
+				row[j] = fmt.Sprintf("%-*s", width, cell)
+			}
+			line = strings.TrimRight(strings.Join(row, " "), " ")
+		}
+		fmt.Fprintf(&s, "%s\n", line)
+	}
+
+	return s.String()
+}
@@ -0,0 +1,242 @@
+package tictactoe
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// play makes the moves, given as row-major square numbers 0-8, and fails the test on any error.
+func play(t *testing.T, squares ...int) *Board {
+	t.Helper()
+
+	b := NewBoard()
+	for _, sq := range squares {
+		if err := b.Play(sq/Size, sq%Size); err != nil {
+			t.Fatalf("Play(%d, %d): %v", sq/Size, sq%Size, err)
+		}
+	}
+
+	return b
+}
+
+func TestWinner(t *testing.T) {
+	tests := []struct {
+		name    string
+		squares []int
+		winner  string
+		status  Status
+	}{
+		{"empty", nil, "", InProgress},
+		{"row", []int{0, 3, 1, 4, 2}, X, Won},
+		{"column", []int{0, 1, 3, 4, 8, 7}, O, Won},
+		{"diagonal", []int{0, 1, 4, 2, 8}, X, Won},
+		{"anti-diagonal", []int{0, 2, 1, 4, 8, 6}, O, Won},
+		{"draw", []int{0, 1, 2, 4, 3, 5, 7, 6, 8}, "", Draw},
+		{"win on the last square", []int{0, 1, 2, 3, 7, 4, 5, 6, 8}, X, Won},
+	}
+
+	for _, tt := range tests {
+		b := play(t, tt.squares...)
+		if got := b.Winner(); got != tt.winner {
+			t.Errorf("%s: Winner() = %q, want %q", tt.name, got, tt.winner)
+		}
+		if got := b.Status(); got != tt.status {
+			t.Errorf("%s: Status() = %v, want %v", tt.name, got, tt.status)
+		}
+	}
+}
+
+func TestPlayErrors(t *testing.T) {
+	b := play(t, 4)
+
+	if err := b.Play(1, 1); !errors.Is(err, ErrOccupied) {
+		t.Errorf("Play on a taken square = %v, want ErrOccupied", err)
+	}
+	if err := b.Play(3, 0); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Play off the board = %v, want ErrOutOfBounds", err)
+	}
+	if got := b.Turn(); got != O {
+		t.Errorf("failed moves changed the turn to %q, want %q", got, O)
+	}
+
+	won := play(t, 0, 3, 1, 4, 2)
+	if err := won.Play(2, 2); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Play after a win = %v, want ErrGameOver", err)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	b := play(t, 0, 4)
+
+	m, err := b.Undo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Move{Player: O, Row: 1, Col: 1}); m != want {
+		t.Errorf("Undo() = %+v, want %+v", m, want)
+	}
+	if b.Cell(1, 1) != Empty || b.Turn() != O || len(b.History()) != 1 {
+		t.Errorf("after Undo: cell %q, turn %q, history %v", b.Cell(1, 1), b.Turn(), b.History())
+	}
+
+	b.Undo()
+	if _, err := b.Undo(); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("Undo on an empty board = %v, want ErrNoHistory", err)
+	}
+
+	// undoing a winning move reopens the game.
+	won := play(t, 0, 3, 1, 4, 2)
+	won.Undo()
+	if won.Status() != InProgress {
+		t.Errorf("Status after undoing the winning move = %v, want %v", won.Status(), InProgress)
+	}
+}
+
+func TestString(t *testing.T) {
+	// the position from the lesson's board demo.
+	b := play(t, 0, 3, 2, 8, 5)
+
+	if got, want := b.String(), "X _ X\nO _ X\n_ _ O\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestInteractive(t *testing.T) {
+	input := strings.Join([]string{
+		"1 1",   // X
+		"1 1",   // taken
+		"4 4",   // off the board
+		"hello", // not a move
+		"2 2",   // O
+		"undo",  // takes back O's move
+		"2 1",   // O
+		"1 2",   // X
+		"2 2",   // O
+		"1 3",   // X wins
+	}, "\n")
+
+	var out strings.Builder
+	b, err := Interactive(strings.NewReader(input), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Winner() != X {
+		t.Fatalf("winner %q, want X; output:\n%s", b.Winner(), out.String())
+	}
+
+	for _, want := range []string{"That square is taken.", "Rows and columns go from 1 to 3.", `Enter a row and a column`, "Took back O at 2 2.", "X wins!"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output doesn't mention %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestInteractiveEOF(t *testing.T) {
+	if _, err := Interactive(strings.NewReader("1 1\n"), io.Discard); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Interactive with truncated input = %v, want io.ErrUnexpectedEOF", err)
+	}
+	if _, err := Interactive(strings.NewReader("quit\n"), io.Discard); err != nil {
+		t.Errorf("Interactive after quit = %v, want nil", err)
+	}
+}
+
+func TestNewBoardConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Config
+		ok   bool
+	}{
+		{"default", DefaultConfig, true},
+		{"gomoku", Config{Size: 15, WinLength: 5, Empty: ".", Players: [2]string{"B", "W"}}, true},
+		{"zero size", Config{Size: 0, WinLength: 1, Empty: Empty, Players: [2]string{X, O}}, false},
+		{"win longer than board", Config{Size: 3, WinLength: 4, Empty: Empty, Players: [2]string{X, O}}, false},
+		{"zero win length", Config{Size: 3, Empty: Empty, Players: [2]string{X, O}}, false},
+		{"same players", Config{Size: 3, WinLength: 3, Empty: Empty, Players: [2]string{X, X}}, false},
+		{"player is empty marker", Config{Size: 3, WinLength: 3, Empty: X, Players: [2]string{X, O}}, false},
+		{"missing marker", Config{Size: 3, WinLength: 3, Players: [2]string{X, O}}, false},
+	}
+
+	for _, tt := range tests {
+		b, err := NewBoardConfig(tt.c)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: NewBoardConfig error = %v, want ok %v", tt.name, err, tt.ok)
+		}
+		if err == nil && b.Config() != tt.c {
+			t.Errorf("%s: Config() = %+v, want %+v", tt.name, b.Config(), tt.c)
+		}
+	}
+}
+
+// playConfig is like play, but on a board described by c; squares are numbered row-major on c's board.
+func playConfig(t *testing.T, c Config, squares ...int) *Board {
+	t.Helper()
+
+	b, err := NewBoardConfig(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sq := range squares {
+		if err := b.Play(sq/c.Size, sq%c.Size); err != nil {
+			t.Fatalf("Play(%d, %d): %v", sq/c.Size, sq%c.Size, err)
+		}
+	}
+
+	return b
+}
+
+func TestWinnerLargeBoards(t *testing.T) {
+	four := Config{Size: 4, WinLength: 4, Empty: Empty, Players: [2]string{X, O}}
+	gomoku := Config{Size: 15, WinLength: 5, Empty: ".", Players: [2]string{"B", "W"}}
+	threeOnFour := Config{Size: 4, WinLength: 3, Empty: Empty, Players: [2]string{X, O}}
+
+	tests := []struct {
+		name    string
+		c       Config
+		squares []int
+		winner  string
+	}{
+		// X fills row 1 of a 4x4 board; O plays along row 2.
+		{"4x4 row", four, []int{4, 8, 5, 9, 6, 10, 7}, X},
+		{"4x4 three isn't enough", four, []int{4, 8, 5, 9, 6}, ""},
+		{"4x4 anti-diagonal", four, []int{3, 0, 6, 1, 9, 2, 12}, X},
+		// three in a row wins anywhere on the board, not just edge to edge.
+		{"3 on 4x4 middle diagonal", threeOnFour, []int{4, 0, 9, 1, 14}, X},
+		// B plays the diagonal from (3,3) to (7,7), the winning move filling the middle.
+		{"gomoku diagonal", gomoku, []int{48, 0, 64, 1, 96, 2, 112, 3, 80}, "B"},
+		{"gomoku broken line", gomoku, []int{48, 0, 64, 1, 96, 80, 112, 3}, ""},
+		{"gomoku column", gomoku, []int{14, 13, 29, 28, 44, 43, 59, 58, 74}, "B"},
+	}
+
+	for _, tt := range tests {
+		b := playConfig(t, tt.c, tt.squares...)
+		if got := b.Winner(); got != tt.winner {
+			t.Errorf("%s: Winner() = %q, want %q\n%s", tt.name, got, tt.winner, b)
+		}
+	}
+}
+
+func TestCustomMarkers(t *testing.T) {
+	c := Config{Size: 3, WinLength: 3, Empty: "·", Players: [2]string{"🐱", "🐶"}}
+	b := playConfig(t, c, 0, 4)
+
+	if got := b.Turn(); got != "🐱" {
+		t.Errorf("Turn() = %q, want the first player", got)
+	}
+	if got, want := b.String(), "🐱 · ·\n· 🐶 ·\n· · ·\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := len(b.Empties()); got != 7 {
+		t.Errorf("len(Empties()) = %d, want 7", got)
+	}
+}
+
+func TestStringPadsWideMarkers(t *testing.T) {
+	c := Config{Size: 2, WinLength: 2, Empty: ".", Players: [2]string{"ab", "cd"}}
+	b := playConfig(t, c, 1)
+
+	if got, want := b.String(), ".  ab\n.  .\n"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"math/big"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/01-Basics/basics"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+var basicsLesson = lesson{
+	name:    "basics",
+	summary: "01-Basics: functions, variables, types and constants",
+	examples: []example{
+		{name: "add", args: "X Y", summary: "print X + Y", run: runAdd},
+		{name: "subtract", args: "X Y", summary: "print X - Y", run: runSubtract},
+		{name: "swap", args: "A B", summary: "print A and B in reverse order", run: runSwap},
+		{name: "split", args: "SUM", summary: "split SUM into four ninths and the remainder", run: runSplit},
+		{name: "needint", args: "X", summary: "print X*10 + 1; X may be a constant expression like 'big >> 99'", run: runNeedInt},
+		{name: "needfloat", args: "X", summary: "print X * 0.1; X may be a constant expression like 'big'", run: runNeedFloat},
+		{name: "eval", args: "EXPR", summary: "evaluate a constant expression like '1 << 100' exactly", run: runEval},
+	},
+}
+
+func runAdd(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	xy, err := intArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	sum := basics.Add(xy[0], xy[1])
+	out.Println(report.Record{Name: "add", Inputs: map[string]any{"x": xy[0], "y": xy[1]}, Result: sum}, sum)
+	return nil
+}
+
+func runSubtract(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	xy, err := intArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	diff := basics.Subtract(xy[0], xy[1])
+	out.Println(report.Record{Name: "subtract", Inputs: map[string]any{"x": xy[0], "y": xy[1]}, Result: diff}, diff)
+	return nil
+}
+
+func runSwap(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	ab, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	a, b := basics.Swap(ab[0], ab[1])
+	out.Println(report.Record{
+		Name:   "swap",
+		Inputs: map[string]any{"x": ab[0], "y": ab[1]},
+		Result: []any{a, b},
+		Type:   "(string, string)",
+	}, a, b)
+	return nil
+}
+
+func runSplit(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	sum, err := intArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	x, y := basics.Split(sum[0])
+	out.Println(report.Record{Name: "split", Inputs: map[string]any{"sum": sum[0]}, Result: []any{x, y}, Type: "(int, int)"}, x, y)
+	return nil
+}
+
+// evalArg parses the single argument as a constant expression and evaluates it exactly.
+func evalArg(fs *flag.FlagSet, args []string) (string, *big.Rat, error) {
+	expr, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	x, err := basics.Eval(expr[0])
+	return expr[0], x, err
+}
+
+func runNeedInt(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	exact := fs.Bool("exact", false, "print the exact result instead of converting it to an int")
+	expr, x, err := evalArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	n, err := basics.Integer(x)
+	if err != nil {
+		return err
+	}
+	to := basics.Int
+	if *exact {
+		to = basics.Exact
+	}
+	v, err := basics.Convert(new(big.Rat).SetInt(basics.NeedIntExact(n)), to)
+	if err != nil {
+		return err
+	}
+
+	out.Println(report.Record{Name: "needint", Inputs: map[string]any{"x": expr}, Result: v}, v)
+	return nil
+}
+
+func runNeedFloat(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	exact := fs.Bool("exact", false, "print the exact result instead of converting it to a float64")
+	expr, x, err := evalArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	to := basics.Float64
+	if *exact {
+		to = basics.Exact
+	}
+	v, err := basics.Convert(basics.NeedFloatExact(x), to)
+	if err != nil {
+		return err
+	}
+
+	out.Println(report.Record{Name: "needfloat", Inputs: map[string]any{"x": expr}, Result: v}, v)
+	return nil
+}
+
+func runEval(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	var to basics.Type
+	fs.Var(&to, "to", "convert the result to int or float64, failing if it overflows (default: print it exactly)")
+	expr, x, err := evalArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	v, err := basics.Convert(x, to)
+	if err != nil {
+		return err
+	}
+
+	out.Println(report.Record{Name: "eval", Inputs: map[string]any{"expr": expr, "to": to.String()}, Result: v}, v)
+	return nil
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/02-FlowControl/flowcontrol"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/02-FlowControl/roots"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+var flowLesson = lesson{
+	name:    "flow",
+	summary: "02-FlowControl: for, if, switch and defer",
+	examples: []example{
+		{name: "sqrt", args: "X", summary: "print the square root of X (negative X gives an imaginary result)", run: runSqrt},
+		{name: "pow", args: "X N LIM", summary: "print X**N, or LIM if X**N >= LIM (-min clamps from below, -int computes exactly)", run: runPow},
+		{name: "root", args: "Z N", summary: "print the principal N-th root of the complex number Z, e.g. -8 or 1+2i", run: runRoot},
+		{name: "nmsqrt", args: "X", summary: "print the square root of X found with Newton's method", run: runNmsqrt},
+		{name: "roots", args: "X", summary: "find the square root of X with Newton, secant, bisection and Brent's method", run: runRoots},
+		{name: "saturday", summary: "print how far away Saturday is", run: runSaturday},
+		{name: "greeting", summary: "print a greeting for the time of day", run: runGreeting},
+	},
+}
+
+func runSqrt(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	x, err := floatArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	root := flowcontrol.Sqrt(x[0])
+	out.Println(report.Record{Name: "sqrt", Inputs: map[string]any{"x": x[0]}, Result: root}, root)
+	return nil
+}
+
+func runPow(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	lower := fs.Float64("min", math.Inf(-1), "also clamp X**N from below at this value")
+	exact := fs.Bool("int", false, "treat X, N, LIM and -min as integers and compute X**N exactly")
+	xnl, err := floatArgs(fs, args, 3)
+	if err != nil {
+		return err
+	}
+
+	inputs := map[string]any{"x": xnl[0], "n": xnl[1], "lim": xnl[2]}
+	if !math.IsInf(*lower, -1) {
+		inputs["min"] = *lower
+	}
+	if *exact {
+		return runIntPow(out, inputs, xnl, *lower)
+	}
+
+	r, err := flowcontrol.ClampPow(xnl[0], xnl[1], flowcontrol.Bounds{Lower: *lower, Upper: xnl[2]})
+	if err != nil {
+		return err
+	}
+	printPow(out, inputs, r.Clamped, r.Raw, r.Value)
+	return nil
+}
+
+// runIntPow is pow -int: X, N, LIM and -min (if set) must be integers, and X**N is computed exactly.
+func runIntPow(out *report.Printer, inputs map[string]any, xnl []float64, lower float64) error {
+	var ints [3]int64
+	for i, name := range []string{"X", "N", "LIM"} {
+		v, err := toInt64(xnl[i])
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		ints[i] = v
+	}
+	b := flowcontrol.IntBounds{Upper: &ints[2]}
+	if !math.IsInf(lower, -1) {
+		lo, err := toInt64(lower)
+		if err != nil {
+			return fmt.Errorf("-min: %w", err)
+		}
+		b.Lower, inputs["min"] = &lo, lo
+	}
+
+	v, clamped, err := flowcontrol.ClampIntPow(ints[0], int(ints[1]), b)
+	if err != nil {
+		return err
+	}
+
+	var raw any
+	if raw, err = flowcontrol.IntPow(ints[0], int(ints[1])); err != nil {
+		raw = "overflow"
+	}
+	inputs["x"], inputs["n"], inputs["lim"], inputs["int"] = ints[0], ints[1], ints[2], true
+	printPow(out, inputs, clamped, raw, v)
+	return nil
+}
+
+// toInt64 converts x to an int64 if it is a whole number in range.
+func toInt64(x float64) (int64, error) {
+	if x != math.Trunc(x) || x < math.MinInt64 || x >= math.MaxInt64 {
+		return 0, fmt.Errorf("%g is not an int64", x)
+	}
+
+	return int64(x), nil
+}
+
+// printPow prints the result of pow. Pow itself prints nothing, so a clamped result is preceded by the
+// comparison that clamped it here, in text mode only; the JSON record shows it by its result. There is
+// only a comparison to print against a bound that is in inputs.
+func printPow(out *report.Printer, inputs map[string]any, clamped flowcontrol.Clamp, raw, v any) {
+	op, key := ">=", "lim"
+	if clamped == flowcontrol.ClampedLow {
+		op, key = "<=", "min"
+	}
+	if bound, ok := inputs[key]; ok && clamped != flowcontrol.Unclamped {
+		out.Textf("%v %s %v\n", raw, op, bound)
+	}
+	out.Println(report.Record{Name: "pow", Inputs: inputs, Result: v}, v)
+}
+
+func runRoot(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	var mode flowcontrol.RootMode
+	fs.Var(&mode, "roots", "which roots to print: principal or all")
+	zn, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	z, err := strconv.ParseComplex(zn[0], 128)
+	if err != nil {
+		return fmt.Errorf("Z: %q is not a complex number", zn[0])
+	}
+	n, err := strconv.Atoi(zn[1])
+	if err != nil {
+		return fmt.Errorf("N: %q is not an integer", zn[1])
+	}
+
+	roots, err := flowcontrol.Roots(z, n, mode)
+	if err != nil {
+		return err
+	}
+	for k, r := range roots {
+		out.Println(report.Record{Name: "root", Inputs: map[string]any{"z": z, "n": n, "k": k}, Result: r}, flowcontrol.FormatComplex(r))
+	}
+	return nil
+}
+
+func runNmsqrt(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	var trace flowcontrol.TraceFormat
+	fs.Var(&trace, "trace", "also show every iterate: table, csv or plot")
+	x, err := floatArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	if trace == "" {
+		r, err := flowcontrol.NewtonSqrt(x[0])
+		if err != nil {
+			return err
+		}
+		out.Println(report.Record{Name: "nmsqrt", Inputs: map[string]any{"x": x[0]}, Result: r.Root}, r.Root)
+		return nil
+	}
+
+	t, err := flowcontrol.NewtonSqrtTrace(x[0])
+	if err != nil {
+		return err
+	}
+	rec := report.Record{
+		Name:   "nmsqrt",
+		Inputs: map[string]any{"x": x[0], "trace": string(trace)},
+		Result: map[string]any{"root": t.Root, "steps": t.Steps},
+	}
+	if out.Format == report.JSON {
+		// the steps are in the record; a table or plot would break the JSON stream.
+		out.Println(rec, t.Root)
+		return nil
+	}
+	if err := t.Write(out.W, trace); err != nil || trace == flowcontrol.TraceCSV {
+		// the CSV has the root in its last row, and can then be redirected to a file as it is.
+		return err
+	}
+	out.Println(rec, "nmsqrt:", t.Root)
+	return nil
+}
+
+func runRoots(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	tol := fs.Float64("tol", roots.DefaultTolerance, "largest acceptable error in the root (relative for roots above 1)")
+	maxIter := fs.Int("maxiter", roots.DefaultMaxIter, "most iterations each method may take")
+	x, err := floatArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	// the square root of x is the positive zero of z*z - x, which lies in [0, max(1, x)].
+	f := func(z float64) float64 { return z*z - x[0] }
+	df := func(z float64) float64 { return 2 * z }
+	hi := max(1, x[0])
+	opts := roots.Options{Tolerance: *tol, MaxIter: *maxIter}
+	methods := []struct {
+		name string
+		find func() (roots.Result, error)
+	}{
+		{"newton", func() (roots.Result, error) { return roots.Newton(f, df, hi, opts) }},
+		{"secant", func() (roots.Result, error) { return roots.Secant(f, 0, hi, opts) }},
+		{"bisection", func() (roots.Result, error) { return roots.Bisection(f, 0, hi, opts) }},
+		{"brent", func() (roots.Result, error) { return roots.Brent(f, 0, hi, opts) }},
+	}
+
+	for _, m := range methods {
+		r, err := m.find()
+		rec := report.Record{
+			Name:   m.name,
+			Inputs: map[string]any{"x": x[0], "tol": opts.Tolerance, "maxiter": opts.MaxIter},
+			Result: map[string]any{"root": r.Root, "iterations": r.Iterations, "error": r.Error, "residual": r.Residual},
+		}
+		if err != nil {
+			rec.Result.(map[string]any)["failure"] = err.Error()
+			out.Printf(rec, "%-10s %v\n", m.name+":", err)
+			continue
+		}
+		out.Printf(rec, "%-10s %v (%d iterations, error %.3g)\n", m.name+":", r.Root, r.Iterations, r.Error)
+	}
+
+	return nil
+}
+
+func runSaturday(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	clock := clockFlags(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, loc, err := clock()
+	if err != nil {
+		return err
+	}
+
+	saturday := flowcontrol.WhenIsSaturday(c, loc)
+	out.Println(report.Record{Name: "saturday", Inputs: map[string]any{"now": c.Now().In(loc)}, Result: saturday}, "Saturday is", saturday)
+	return nil
+}
+
+func runGreeting(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	clock := clockFlags(fs)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, loc, err := clock()
+	if err != nil {
+		return err
+	}
+
+	greeting := flowcontrol.Greeting(c, loc)
+	out.Println(report.Record{Name: "greeting", Inputs: map[string]any{"now": c.Now().In(loc)}, Result: greeting}, greeting)
+	return nil
+}
+
+// clockFlags defines the -now and -tz flags on fs. After parsing, call the returned function
+// to get the Clock and time zone they select.
+func clockFlags(fs *flag.FlagSet) func() (flowcontrol.Clock, *time.Location, error) {
+	now := fs.String("now", "", "pin the current time (RFC 3339) instead of reading the system clock")
+	tz := fs.String("tz", "Local", "IANA time zone name")
+
+	return func() (flowcontrol.Clock, *time.Location, error) {
+		loc, err := time.LoadLocation(*tz)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid -tz: %v", err)
+		}
+
+		if *now == "" {
+			return flowcontrol.SystemClock{}, loc, nil
+		}
+
+		t, err := time.Parse(time.RFC3339, *now)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid -now: %v", err)
+		}
+
+		return flowcontrol.FixedClock(t), loc, nil
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/geo"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+// coordArgs returns the positional arguments as n/2 validated coordinates, each given as LAT LONG.
+func coordArgs(fs *flag.FlagSet, args []string, n int) ([]moretypes.Coord, error) {
+	f, err := floatArgs(fs, args, 2*n)
+	if err != nil {
+		return nil, err
+	}
+
+	coords := make([]moretypes.Coord, n)
+	for i := range coords {
+		coords[i] = moretypes.Coord{Lat: f[2*i], Long: f[2*i+1]}
+		if err := geo.Validate(coords[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return coords, nil
+}
+
+func runDistance(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	c, err := coordArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	haversine, bearing := geo.Haversine(c[0], c[1]), geo.Bearing(c[0], c[1])
+	result := map[string]any{"haversine_m": haversine, "vincenty_m": nil, "bearing_deg": bearing}
+	vincenty := "did not converge"
+	if d, err := geo.Vincenty(c[0], c[1]); err == nil {
+		result["vincenty_m"] = d
+		vincenty = fmt.Sprintf("%.3f km", d/1000)
+	}
+
+	out.Printf(report.Record{Name: "distance", Inputs: map[string]any{"from": c[0], "to": c[1]}, Result: result},
+		"haversine: %.3f km\nvincenty:  %s\nbearing:   %.2f°\n", haversine/1000, vincenty, bearing)
+	return nil
+}
+
+// placesFlag defines the -places flag that names a catalog file to use instead of the lesson's cities.
+func placesFlag(fs *flag.FlagSet) *string {
+	return fs.String("places", "", "CSV, JSON or GeoJSON catalog of places, by extension (default: the lesson's cities)")
+}
+
+// loadPlaces reads the catalog at path, or returns the lesson's cities if path is empty.
+func loadPlaces(path string) (map[string]moretypes.Coord, error) {
+	if path == "" {
+		return geo.Cities, nil
+	}
+
+	format, err := geo.FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	places, err := geo.Read(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s:\n%w", path, err)
+	}
+	return places, nil
+}
+
+func runNearest(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	k := fs.Int("k", 3, "number of places to list (negative for all)")
+	path := placesFlag(fs)
+	c, err := coordArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	places, err := loadPlaces(*path)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range geo.Nearest(places, c[0], *k) {
+		out.Printf(report.Record{Name: "nearest", Inputs: map[string]any{"from": c[0], "k": *k}, Result: n, Type: "geo.Neighbor"},
+			"%-16s %9.1f km  (%g, %g)\n", n.Name, n.Distance/1000, n.Coord.Lat, n.Coord.Long)
+	}
+	return nil
+}
+
+func runPlaces(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	path := placesFlag(fs)
+	to := geo.CSV
+	fs.Var(&to, "to", "format to write: csv, json or geojson")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	places, err := loadPlaces(*path)
+	if err != nil {
+		return err
+	}
+
+	if out.Format == report.JSON {
+		out.Println(report.Record{Name: "places", Result: places}, places)
+		return nil
+	}
+	return geo.Write(out.W, places, to)
+}
+
+func runRoute(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	path := placesFlag(fs)
+	start := fs.String("start", "", "place to start and end at (default: the first by name)")
+	exact := fs.Bool("exact", false, fmt.Sprintf("find the shortest route by trying every order (at most %d places)", geo.MaxExact))
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	places, err := loadPlaces(*path)
+	if err != nil {
+		return err
+	}
+
+	plan, method := geo.PlanRoute, "2-opt"
+	if *exact {
+		plan, method = geo.ExactRoute, "exact"
+	}
+	route, err := plan(places, *start)
+	if err != nil {
+		return err
+	}
+
+	var text strings.Builder
+	for _, leg := range route.Legs(places) {
+		fmt.Fprintf(&text, "%-16s -> %-16s %8.1f km\n", leg.From, leg.To, leg.Distance/1000)
+	}
+	fmt.Fprintf(&text, "total: %.1f km\n", route.Distance/1000)
+	out.Printf(report.Record{Name: "route", Inputs: map[string]any{"start": route.Stops[0], "method": method}, Result: route, Type: "geo.Route"}, "%s", text.String())
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/picture"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+// runImage renders Pic as an image file (see package picture).
+func runImage(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	width := fs.Int("width", 256, "image width in pixels (Pic's dx)")
+	height := fs.Int("height", 256, "image height in pixels (Pic's dy)")
+	gen := generatorFlag(fs)
+	mode := modeFlag(fs)
+	encoding := picture.PNG
+	fs.Var(&encoding, "encoding", "image encoding: png or pgm")
+	palette := picture.Blue
+	fs.Var(&palette, "palette", "PNG palette: blue or gray (PGM is always gray)")
+	path := fs.String("o", "", `output file, or "-" for standard output (default "pic.<encoding>")`)
+
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if *width <= 0 || *height <= 0 {
+		return fmt.Errorf("-width and -height must be positive, got %dx%d", *width, *height)
+	}
+	if *path == "" {
+		*path = "pic." + string(encoding)
+	}
+
+	fn, err := picture.Lookup(*gen)
+	if err != nil {
+		return err
+	}
+	pic := moretypes.PicMode(*width, *height, fn, *mode)
+
+	if *path == "-" {
+		if out.Format == report.JSON {
+			return errors.New("-o - can't be combined with -format=json")
+		}
+		return picture.Encode(out.W, pic, encoding, palette)
+	}
+
+	f, err := os.Create(*path)
+	if err != nil {
+		return err
+	}
+	if err := picture.Encode(f, pic, encoding, palette); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	out.Printf(report.Record{
+		Name:   "image",
+		Inputs: map[string]any{"width": *width, "height": *height, "func": *gen, "mode": *mode, "encoding": encoding, "palette": palette},
+		Result: *path,
+	}, "wrote %dx%d %s of %s to %s\n", *width, *height, encoding, *gen, *path)
+	return nil
+}
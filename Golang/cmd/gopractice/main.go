@@ -0,0 +1,285 @@
+// Command gopractice runs the examples from the Go lessons one at a time.
+//
+// Usage:
+//
+//	gopractice <lesson> <example> [flags] [arguments]
+//
+// For example:
+//
+//	gopractice basics split 17
+//	gopractice flow nmsqrt 2
+//	gopractice types pic 8 8
+//	gopractice types fib 10
+//	gopractice basics swap hello world -format=json
+//
+// Run "gopractice help" for the list of lessons and "gopractice help <lesson>" for a lesson's examples.
+//
+// "gopractice serve [-addr host:port]" serves the same examples as a JSON HTTP API instead.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+// lesson groups the examples of one lesson under a subcommand.
+type lesson struct {
+	name     string
+	summary  string
+	examples []example
+}
+
+// example is a single runnable example of a lesson.
+type example struct {
+	name    string
+	args    string // synopsis of the positional arguments, e.g. "X Y"
+	summary string
+
+	// run defines any flags it needs on fs, parses args with parseArgs, and prints its result with out.
+	run func(fs *flag.FlagSet, out *report.Printer, args []string) error
+}
+
+// errUsage reports that the command line was malformed; the message has already been printed.
+var errUsage = errors.New("usage")
+
+func lessons() []lesson {
+	return []lesson{basicsLesson, flowLesson, typesLesson}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the process exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		if len(args) > 1 {
+			if l, ok := findLesson(args[1]); ok {
+				lessonUsage(stdout, l)
+				return 0
+			}
+			fmt.Fprintf(stderr, "gopractice: unknown lesson %q\n", args[1])
+			return 2
+		}
+		usage(stdout)
+		return 0
+	}
+
+	if args[0] == "serve" {
+		return runServe(args[1:], stderr)
+	}
+
+	l, ok := findLesson(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "gopractice: unknown lesson %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	if len(args) < 2 {
+		lessonUsage(stderr, l)
+		return 2
+	}
+
+	ex, ok := findExample(l, args[1])
+	if !ok {
+		fmt.Fprintf(stderr, "gopractice %s: unknown example %q\n", l.name, args[1])
+		lessonUsage(stderr, l)
+		return 2
+	}
+
+	name := "gopractice " + l.name + " " + ex.name
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: %s [flags] %s\n\n%s\n", name, ex.args, ex.summary)
+		if hasFlags(fs) {
+			fmt.Fprintln(stderr, "\nflags:")
+			fs.PrintDefaults()
+		}
+	}
+
+	// every example supports -format; the flag writes straight into the printer.
+	out := report.NewPrinter(stdout, report.Text)
+	fs.Var(&out.Format, "format", "output format: text or json")
+
+	if err := ex.run(fs, out, args[2:]); err != nil {
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		}
+		fmt.Fprintf(stderr, "%s: %v\n", name, err)
+		return 1
+	}
+
+	return 0
+}
+
+func findLesson(name string) (lesson, bool) {
+	for _, l := range lessons() {
+		if l.name == name {
+			return l, true
+		}
+	}
+
+	return lesson{}, false
+}
+
+func findExample(l lesson, name string) (example, bool) {
+	for _, ex := range l.examples {
+		if ex.name == name {
+			return ex, true
+		}
+	}
+
+	return example{}, false
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: gopractice <lesson> <example> [flags] [arguments]")
+	fmt.Fprintln(w, "\nlessons:")
+	for _, l := range lessons() {
+		fmt.Fprintf(w, "  %-8s %s\n", l.name, l.summary)
+	}
+	fmt.Fprintln(w, "\nRun \"gopractice help <lesson>\" for the examples in a lesson,")
+	fmt.Fprintln(w, "or \"gopractice serve\" to serve them as a JSON HTTP API.")
+}
+
+func lessonUsage(w io.Writer, l lesson) {
+	fmt.Fprintf(w, "usage: gopractice %s <example> [flags] [arguments]\n", l.name)
+	fmt.Fprintln(w, "\nexamples:")
+	for _, ex := range l.examples {
+		fmt.Fprintf(w, "  %-24s %s\n", strings.TrimSpace(ex.name+" "+ex.args), ex.summary)
+	}
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	found := false
+	fs.VisitAll(func(*flag.Flag) { found = true })
+
+	return found
+}
+
+// parseArgs parses flags and positional arguments from args, which may be interleaved
+// (e.g. "pic 8 8 -out pic.png"), and checks that exactly n positional arguments were given,
+// or at least one if n is anyArgs.
+//
+// Negative numbers such as "-4" are positional arguments, not flags. A flag that takes a
+// negative value must be written as -flag=-4. Everything after "--" is positional, dashes or not.
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+	for len(args) > 0 {
+		if args[0] == "--" {
+			positional = append(positional, args[1:]...)
+			break
+		}
+		if a := args[0]; a == "-" || !strings.HasPrefix(a, "-") || isNumber(a) {
+			positional = append(positional, a)
+			args = args[1:]
+			continue
+		}
+
+		// let the flag package parse up to the next negative number or "--"; the flag package would drop
+		// the "--" itself, and the loop needs to see it.
+		end := len(args)
+		for i := 1; i < len(args); i++ {
+			if args[i] == "--" || strings.HasPrefix(args[i], "-") && isNumber(args[i]) {
+				end = i
+				break
+			}
+		}
+
+		if err := fs.Parse(args[:end]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+		args = append(fs.Args(), args[end:]...)
+	}
+
+	if n == anyArgs && len(positional) == 0 {
+		fmt.Fprintf(fs.Output(), "%s: want at least 1 argument\n", fs.Name())
+		fs.Usage()
+		return nil, errUsage
+	}
+	if n != anyArgs && len(positional) != n {
+		fmt.Fprintf(fs.Output(), "%s: want %d argument(s), got %d\n", fs.Name(), n, len(positional))
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	return positional, nil
+}
+
+// anyArgs tells parseArgs to accept one or more positional arguments.
+const anyArgs = -1
+
+// isNumber reports whether s is a number, real or complex, so that "-2" or "-1+2i" is an argument rather
+// than a flag.
+func isNumber(s string) bool {
+	_, err := strconv.ParseComplex(s, 128)
+	return err == nil
+}
+
+// parseInts converts every argument to an int.
+func parseInts(args []string) ([]int, error) {
+	ints := make([]int, len(args))
+	for i, a := range args {
+		v, err := strconv.Atoi(a)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %q is not an integer", i+1, a)
+		}
+		ints[i] = v
+	}
+
+	return ints, nil
+}
+
+// parseFloats converts every argument to a float64.
+func parseFloats(args []string) ([]float64, error) {
+	floats := make([]float64, len(args))
+	for i, a := range args {
+		v, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %q is not a number", i+1, a)
+		}
+		floats[i] = v
+	}
+
+	return floats, nil
+}
+
+// intArgs parses n positional arguments with parseArgs and converts them to ints.
+func intArgs(fs *flag.FlagSet, args []string, n int) ([]int, error) {
+	positional, err := parseArgs(fs, args, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInts(positional)
+}
+
+// floatArgs parses n positional arguments with parseArgs and converts them to float64s.
+func floatArgs(fs *flag.FlagSet, args []string, n int) ([]float64, error) {
+	positional, err := parseArgs(fs, args, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFloats(positional)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args   []string
+		code   int
+		stdout string // the whole of standard output
+		stderr string // a substring of standard error, or "" for none at all
+	}{
+		{[]string{"flow", "sqrt", "-4"}, 0, "2i\n", ""},
+		{[]string{"flow", "sqrt", "2"}, 0, "1.4142135623730951\n", ""},
+		{[]string{"flow", "pow", "-2", "3", "10"}, 0, "-8\n", ""},
+		{[]string{"flow", "pow", "3", "3", "20"}, 0, "27 >= 20\n20\n", ""},
+		{[]string{"flow", "pow", "-min=-5", "-2", "3", "10"}, 0, "-8 <= -5\n-5\n", ""},
+		{[]string{"flow", "pow", "-int", "-2", "63", "0"}, 0, "-9223372036854775808\n", ""},
+		{[]string{"flow", "pow", "-int", "-min=-9223372036854775808", "-2", "63", "0"}, 0, "-9223372036854775808 <= -9223372036854775808\n-9223372036854775808\n", ""},
+		{[]string{"flow", "pow", "-int", "3", "40", "100"}, 0, "overflow >= 100\n100\n", ""},
+		{[]string{"flow", "pow", "-int", "-3", "41", "100"}, 1, "", "integer power overflows int64"},
+		{[]string{"flow", "root", "-1+2i", "2"}, 0, "0.7861513777574233+1.272019649514069i\n", ""},
+		{[]string{"basics", "split", "17"}, 0, "7 10\n", ""},
+		{[]string{"basics", "swap", "--", "-format", "-x"}, 0, "-x -format\n", ""},
+		{[]string{"types", "pic", "2", "2"}, 0, "[0 1]\n[1 2]\n", ""},
+
+		// flags may come before, between or after the arguments.
+		{[]string{"basics", "swap", "-format=json", "hello", "world"}, 0, `{"name":"swap","inputs":{"x":"hello","y":"world"},"result":["world","hello"],"type":"(string, string)"}` + "\n", ""},
+		{[]string{"basics", "swap", "hello", "-format", "json", "world"}, 0, `{"name":"swap","inputs":{"x":"hello","y":"world"},"result":["world","hello"],"type":"(string, string)"}` + "\n", ""},
+		{[]string{"flow", "pow", "-2", "-format=json", "3", "10"}, 0, `{"name":"pow","inputs":{"lim":10,"n":3,"x":-2},"result":-8,"type":"float64"}` + "\n", ""},
+
+		// help goes to standard output and succeeds.
+		{[]string{"help"}, 0, "", ""},
+		{[]string{"help", "flow"}, 0, "", ""},
+		{[]string{"flow", "sqrt", "-h"}, 0, "", "usage: gopractice flow sqrt [flags] X"},
+
+		// bad command lines print usage and exit with 2.
+		{nil, 2, "", "usage: gopractice <lesson> <example>"},
+		{[]string{"nope"}, 2, "", `gopractice: unknown lesson "nope"`},
+		{[]string{"help", "nope"}, 2, "", `gopractice: unknown lesson "nope"`},
+		{[]string{"flow"}, 2, "", "usage: gopractice flow <example>"},
+		{[]string{"flow", "nope"}, 2, "", `gopractice flow: unknown example "nope"`},
+		{[]string{"flow", "sqrt"}, 2, "", "gopractice flow sqrt: want 1 argument(s), got 0"},
+		{[]string{"flow", "sqrt", "1", "2"}, 2, "", "want 1 argument(s), got 2"},
+		{[]string{"flow", "pow", "-bogus", "1", "2", "3"}, 2, "", "flag provided but not defined: -bogus"},
+		{[]string{"flow", "pow", "-min", "-5", "1", "2", "3"}, 2, "", "flag needs an argument: -min"},
+
+		// arguments that parse but can't be used are errors, with exit status 1.
+		{[]string{"flow", "sqrt", "abc"}, 1, "", `gopractice flow sqrt: argument 1: "abc" is not a number`},
+		{[]string{"types", "pic", "1025", "1"}, 1, "", "dimensions must be between 0 and 1024, got 1025x1"},
+		{[]string{"types", "pic", "100000000", "100000000"}, 1, "", "dimensions must be between 0 and 1024"},
+		{[]string{"flow", "nmsqrt", "-4"}, 1, "", "gopractice flow nmsqrt: flowcontrol: square root of a negative number"},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		code := run(tt.args, &stdout, &stderr)
+		if code != tt.code {
+			t.Errorf("run(%q) = %d, want %d\nstderr: %s", tt.args, code, tt.code, stderr.String())
+		}
+		if tt.stdout != "" && stdout.String() != tt.stdout {
+			t.Errorf("run(%q) printed %q, want %q", tt.args, stdout.String(), tt.stdout)
+		}
+		if tt.stderr == "" && stderr.Len() > 0 {
+			t.Errorf("run(%q) wrote to stderr: %s", tt.args, stderr.String())
+		}
+		if !strings.Contains(stderr.String(), tt.stderr) {
+			t.Errorf("run(%q) stderr = %q, want it to contain %q", tt.args, stderr.String(), tt.stderr)
+		}
+	}
+}
+
+func TestHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"help"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("help exited with %d", code)
+	}
+	for _, l := range lessons() {
+		if !strings.Contains(stdout.String(), "  "+l.name+" ") {
+			t.Errorf("help does not list lesson %s:\n%s", l.name, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := run([]string{"help", "flow"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("help flow exited with %d", code)
+	}
+	for _, ex := range flowLesson.examples {
+		if !strings.Contains(stdout.String(), "  "+strings.TrimSpace(ex.name+" "+ex.args)+" ") {
+			t.Errorf("help flow does not list %s:\n%s", ex.name, stdout.String())
+		}
+	}
+
+	// an example's usage lists its flags.
+	stderr.Reset()
+	run([]string{"flow", "pow", "-help"}, &stdout, &stderr)
+	for _, s := range []string{"usage: gopractice flow pow [flags] X N LIM", "-format", "-min", "-int"} {
+		if !strings.Contains(stderr.String(), s) {
+			t.Errorf("flow pow -help does not mention %q:\n%s", s, stderr.String())
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+		flag string
+	}{
+		{[]string{"8", "8"}, []string{"8", "8"}, ""},
+		{[]string{"-out", "pic.png", "8", "8"}, []string{"8", "8"}, "pic.png"},
+		{[]string{"8", "-out", "pic.png", "8"}, []string{"8", "8"}, "pic.png"},
+		{[]string{"8", "8", "-out=pic.png"}, []string{"8", "8"}, "pic.png"},
+		{[]string{"-4", "-1+2i"}, []string{"-4", "-1+2i"}, ""},
+		{[]string{"-out", "x", "-2.5e3", "-Inf"}, []string{"-2.5e3", "-Inf"}, "x"},
+		{[]string{"-", "-out=-3", "8"}, []string{"-", "8"}, "-3"},
+		{[]string{"--", "-out", "x"}, []string{"-out", "x"}, ""},
+		{[]string{"-out", "x", "--", "-out", "-y"}, []string{"-out", "-y"}, "x"},
+		{[]string{"8", "--", "-out=y"}, []string{"8", "-out=y"}, ""},
+	}
+
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		out := fs.String("out", "", "")
+		got, err := parseArgs(fs, tt.args, 2)
+		if err != nil || !reflect.DeepEqual(got, tt.want) || *out != tt.flag {
+			t.Errorf("parseArgs(%q) = %q, -out %q, %v; want %q, -out %q", tt.args, got, *out, err, tt.want, tt.flag)
+		}
+	}
+}
+
+func TestIsNumber(t *testing.T) {
+	for s, want := range map[string]bool{
+		"-4": true, "-1.5": true, "-1+2i": true, "-2i": true, "-Inf": true, "-1e9": true,
+		"-format": false, "-h": false, "--help": false, "-": false, "-i": false,
+	} {
+		if got := isNumber(s); got != want {
+			t.Errorf("isNumber(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
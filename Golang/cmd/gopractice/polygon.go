@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/geometry"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+func runPolygon(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	n, err := intArgs(fs, args, anyArgs)
+	if err != nil {
+		return err
+	}
+	if len(n) < 6 || len(n)%2 != 0 {
+		return fmt.Errorf("want at least three X Y pairs, got %d number(s)", len(n))
+	}
+
+	p := make(geometry.Polygon, len(n)/2)
+	for i := range p {
+		p[i] = moretypes.Vertex{X: n[2*i], Y: n[2*i+1]}
+	}
+	inputs := map[string]any{"polygon": p}
+
+	out.Printf(report.Record{Name: "area", Inputs: inputs, Result: p.Area()}, "area:      %g\n", p.Area())
+	out.Printf(report.Record{Name: "perimeter", Inputs: inputs, Result: p.Perimeter()}, "perimeter: %.6g\n", p.Perimeter())
+	if c, err := p.Centroid(); err == nil {
+		out.Printf(report.Record{Name: "centroid", Inputs: inputs, Result: c}, "centroid:  {%.6g %.6g}\n", c.X, c.Y)
+	} else {
+		out.Printf(report.Record{Name: "centroid", Inputs: inputs, Result: nil}, "centroid:  none (%v)\n", err)
+	}
+	hull := geometry.ConvexHull(p)
+	out.Printf(report.Record{Name: "convex hull", Inputs: inputs, Result: hull, Type: "geometry.Polygon"}, "hull:      %v\n", hull)
+	return nil
+}
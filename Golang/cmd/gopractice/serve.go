@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/api"
+)
+
+// runServe implements "gopractice serve", which serves the lesson functions over HTTP (see package api).
+func runServe(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gopractice serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	origin := fs.String("allow-origin", "", "value for the Access-Control-Allow-Origin header, e.g. http://localhost:4200")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: gopractice serve [flags]\n\nserve the lesson functions as a JSON HTTP API\n\nflags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "gopractice serve: unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	logger := log.New(stderr, "gopractice serve: ", log.LstdFlags)
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           api.NewHandler(api.Options{AllowOrigin: *origin, ErrorLog: logger}),
+		ReadHeaderTimeout: 5 * time.Second,
+		ErrorLog:          logger,
+	}
+
+	logger.Printf("listening on http://%s", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		logger.Print(err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/tictactoe"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+// runTicTacToe plays an interactive game of tic-tac-toe on standard input, between two people or, with
+// -computer, against the machine.
+func runTicTacToe(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	var level tictactoe.Level
+	fs.Var(&level, "level", "how well the computer plays: random, greedy or perfect")
+	computer := fs.String("computer", "", "marker the computer plays, X or O (default: two people play)")
+	size := fs.Int("size", tictactoe.Size, "number of rows and columns")
+	win := fs.Int("win", 0, "markers in a row needed to win (default: the board size, at most 5)")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if out.Format == report.JSON {
+		return errors.New("the interactive game has no JSON output")
+	}
+
+	config := tictactoe.DefaultConfig
+	config.Size, config.WinLength = *size, *win
+	if config.WinLength == 0 {
+		config.WinLength = min(*size, 5)
+	}
+	b, err := tictactoe.NewBoardConfig(config)
+	if err != nil {
+		return err
+	}
+
+	if *computer == "" {
+		if level != "" {
+			return errors.New("-level needs -computer")
+		}
+		return b.Interactive(os.Stdin, out.W)
+	}
+	return b.PlayComputer(os.Stdin, out.W, tictactoe.Computer{Level: level}, *computer)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/picture"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/api"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+var typesLesson = lesson{
+	name:    "types",
+	summary: "03-MoreTypes: pointers, structs, slices, maps and closures",
+	examples: []example{
+		{name: "pic", args: "DX DY", summary: "print the DY rows of DX pixel values built by Pic (-func, -mode)", run: runPic},
+		{name: "image", summary: "render Pic as a PNG or PGM image (-width, -height, -func, -mode, -encoding, -palette, -o)", run: runImage},
+		{name: "generators", summary: "list the pixel functions -func can select", run: runGenerators},
+		{name: "tictactoe", summary: "play tic-tac-toe in the terminal, against another person or the computer (-computer, -level, -size, -win)", run: runTicTacToe},
+		{name: "distance", args: "LAT1 LONG1 LAT2 LONG2", summary: "print the haversine and Vincenty distances and the bearing between two points", run: runDistance},
+		{name: "nearest", args: "LAT LONG", summary: "list the lesson's cities, or a catalog's places, nearest a point (-k, -places)", run: runNearest},
+		{name: "route", summary: "plan a round trip through the lesson's cities or a catalog's places (-start, -exact, -places)", run: runRoute},
+		{name: "places", summary: "convert a catalog of places between CSV, JSON and GeoJSON (-places, -to)", run: runPlaces},
+		{name: "vector", args: "X1 Y1 X2 Y2", summary: "apply the Vertex vector operations to V = (X1, Y1) and W = (X2, Y2) (-rotate)", run: runVector},
+		{name: "polygon", args: "X1 Y1 X2 Y2 X3 Y3...", summary: "print the area, perimeter, centroid and convex hull of a polygon", run: runPolygon},
+		{name: "fib", args: "N", summary: "print the first N Fibonacci numbers", run: runFib},
+		{name: "adder", args: "X...", summary: "print the running sum after adding each X", run: runAdder},
+		{name: "compute", args: "FUNC", summary: "print FUNC(3, 4), where FUNC is hypot or pow", run: runCompute},
+	},
+}
+
+func runPic(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	gen := generatorFlag(fs)
+	mode := modeFlag(fs)
+	d, err := intArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	if d[0] < 0 || d[1] < 0 || d[0] > api.MaxPicSize || d[1] > api.MaxPicSize {
+		return fmt.Errorf("dimensions must be between 0 and %d, got %dx%d", api.MaxPicSize, d[0], d[1])
+	}
+
+	fn, err := picture.Lookup(*gen)
+	if err != nil {
+		return err
+	}
+	pic := moretypes.PicMode(d[0], d[1], fn, *mode)
+
+	var rows strings.Builder
+	for _, line := range pic {
+		fmt.Fprintln(&rows, line)
+	}
+	out.Printf(report.Record{Name: "pic", Inputs: map[string]any{"dx": d[0], "dy": d[1], "func": *gen, "mode": *mode}, Result: pic}, "%s", rows.String())
+	return nil
+}
+
+func runGenerators(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	for _, g := range picture.Generators() {
+		out.Printf(report.Record{Name: "generator", Result: map[string]any{"name": g.Name, "description": g.Description}, Type: "picture.Generator"},
+			"%-14s %s\n", g.Name, g.Description)
+	}
+	return nil
+}
+
+// modeFlag defines the -mode flag that selects how out-of-range pixel values are handled.
+func modeFlag(fs *flag.FlagSet) *moretypes.Mode {
+	mode := moretypes.Wrap
+	fs.Var(&mode, "mode", "how values outside 0-255 are handled: wrap, clamp or normalize")
+
+	return &mode
+}
+
+// generatorFlag defines the -func flag that selects a pixel function from the picture package's catalog.
+func generatorFlag(fs *flag.FlagSet) *string {
+	return fs.String("func", picture.DefaultGenerator, `pixel function (see "gopractice types generators")`)
+}
+
+func runFib(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	n, err := intArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	fib := moretypes.Fibonacci()
+	for i := 0; i < n[0]; i++ {
+		v := fib()
+		out.Println(report.Record{Name: "fib", Inputs: map[string]any{"call": i + 1}, Result: v}, v)
+	}
+	return nil
+}
+
+func runAdder(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	xs, err := intArgs(fs, args, anyArgs)
+	if err != nil {
+		return err
+	}
+
+	sum := moretypes.Adder()
+	for _, x := range xs {
+		v := sum(x)
+		out.Println(report.Record{Name: "adder", Inputs: map[string]any{"x": x}, Result: v}, v)
+	}
+	return nil
+}
+
+func runCompute(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	name, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	fn, err := moretypes.ComputeFunc(name[0])
+	if err != nil {
+		return err
+	}
+
+	v := moretypes.Compute(fn)
+	out.Println(report.Record{Name: "compute", Inputs: map[string]any{"fn": name[0]}, Result: v}, v)
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+func runVector(fs *flag.FlagSet, out *report.Printer, args []string) error {
+	degrees := fs.Float64("rotate", 90, "angle in degrees to rotate V by, counterclockwise")
+	n, err := intArgs(fs, args, 4)
+	if err != nil {
+		return err
+	}
+
+	v, w := moretypes.Vertex{X: n[0], Y: n[1]}, moretypes.Vertex{X: n[2], Y: n[3]}
+	inputs := map[string]any{"v": v, "w": w}
+	results := []struct {
+		name   string
+		result any
+	}{
+		{"v + w", v.Add(w)},
+		{"v - w", v.Sub(w)},
+		{"2v", v.Scale(2)},
+		{"v · w", v.Dot(w)},
+		{"v × w", v.Cross(w)},
+		{"|v|", v.Length()},
+		{"v / |v|", v.Normalize()},
+		{fmt.Sprintf("v rotated %g°", *degrees), v.Rotate(*degrees * math.Pi / 180)},
+		{"v == w", v.Equal(w)},
+	}
+	for _, r := range results {
+		text := fmt.Sprint(r.result)
+		if vec, ok := r.result.(moretypes.Vec); ok {
+			// hide rounding noise such as 3.0000000000000004 in the text output.
+			text = fmt.Sprintf("{%.6g %.6g}", vec.X, vec.Y)
+		}
+		out.Printf(report.Record{Name: r.name, Inputs: inputs, Result: r.result}, "%-16s %s\n", r.name, text)
+	}
+	return nil
+}
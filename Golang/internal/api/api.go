@@ -0,0 +1,599 @@
+// Package api serves the lesson functions over HTTP so the Angular side of the course can call them.
+//
+// Every endpoint is a GET request that takes its arguments as query parameters and answers with a JSON
+// report.Record, the same structure gopractice prints with -format=json:
+//
+//	GET /basics/add?x=42&y=13
+//	{"name":"add","inputs":{"x":42,"y":13},"result":55,"type":"int"}
+//
+// Missing or malformed parameters get a 400 Bad Request with a JSON body of the form {"error": "..."}.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/01-Basics/basics"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/02-FlowControl/flowcontrol"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/moretypes"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/03-MoreTypes/picture"
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/internal/report"
+)
+
+// Limits on request sizes, so a single request can't tie up the server.
+const (
+	MaxPicSize    = 1024 // largest dx or dy accepted by /types/pic
+	MaxFibonacci  = 92   // largest n accepted by /types/fibonacci; F(93) overflows int64
+	MaxRootDegree = 1000 // largest n accepted by /flow/root
+	MaxExprLen    = 1024 // longest expr, in bytes, accepted by /basics/eval
+)
+
+// Options configures the handler returned by NewHandler.
+type Options struct {
+	// Clock is used by /flow/saturday and /flow/greeting when the request has no "now" parameter.
+	// Nil means flowcontrol.SystemClock.
+	Clock flowcontrol.Clock
+
+	// AllowOrigin, if set, is sent as the Access-Control-Allow-Origin header so a browser app served
+	// from another origin (e.g. the Angular dev server on http://localhost:4200) can call the API.
+	AllowOrigin string
+
+	// ErrorLog receives errors writing responses. Nil means the log package's standard logger.
+	ErrorLog *log.Logger
+}
+
+// endpoint computes the record for one request, or returns a *paramError if the query is invalid.
+type endpoint func(q url.Values) (report.Record, error)
+
+// NewHandler returns an http.Handler serving every lesson endpoint.
+func NewHandler(opts Options) http.Handler {
+	if opts.Clock == nil {
+		opts.Clock = flowcontrol.SystemClock{}
+	}
+	if opts.ErrorLog == nil {
+		opts.ErrorLog = log.Default()
+	}
+
+	routes := map[string]endpoint{
+		"/basics/add":       basicsAdd,
+		"/basics/subtract":  basicsSubtract,
+		"/basics/swap":      basicsSwap,
+		"/basics/split":     basicsSplit,
+		"/basics/needint":   basicsNeedInt,
+		"/basics/needfloat": basicsNeedFloat,
+		"/basics/eval":      basicsEval,
+
+		"/flow/sqrt":     flowSqrt,
+		"/flow/pow":      flowPow,
+		"/flow/nmsqrt":   flowNmsqrt,
+		"/flow/root":     flowRoot,
+		"/flow/saturday": flowSaturday(opts.Clock),
+		"/flow/greeting": flowGreeting(opts.Clock),
+
+		"/types/pic":       typesPic,
+		"/types/fibonacci": typesFibonacci,
+		"/types/adder":     typesAdder,
+		"/types/compute":   typesCompute,
+		"/types/vector":    typesVector,
+	}
+
+	mux := http.NewServeMux()
+	for path, fn := range routes {
+		mux.Handle("GET "+path, serve(fn, opts))
+	}
+
+	return mux
+}
+
+// serve adapts an endpoint to an http.Handler.
+func serve(fn endpoint, opts Options) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if opts.AllowOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", opts.AllowOrigin)
+		}
+
+		respond := func(status int, v any) {
+			if err := writeJSON(w, status, v); err != nil {
+				opts.ErrorLog.Printf("%s: writing response: %v", r.URL.Path, err)
+			}
+		}
+
+		rec, err := fn(r.URL.Query())
+		if err != nil {
+			var perr *paramError
+			if errors.As(err, &perr) {
+				respond(http.StatusBadRequest, errorBody{Error: err.Error()})
+				return
+			}
+			respond(http.StatusInternalServerError, errorBody{Error: err.Error()})
+			return
+		}
+
+		respond(http.StatusOK, report.Normalize(rec))
+	})
+}
+
+// errorBody is the JSON body of an error response.
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes v as the JSON body of a response with the given status. The status has been sent by
+// the time an error is returned, so all the caller can do with it is log it.
+func writeJSON(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
+// paramError reports a missing or malformed query parameter.
+type paramError struct {
+	name string
+	msg  string
+}
+
+func (e *paramError) Error() string {
+	return fmt.Sprintf("parameter %q: %s", e.name, e.msg)
+}
+
+// param returns the single value of the query parameter name.
+func param(q url.Values, name string) (string, error) {
+	values, ok := q[name]
+	switch {
+	case !ok || len(values) == 0:
+		return "", &paramError{name, "required"}
+	case len(values) > 1:
+		return "", &paramError{name, "given more than once"}
+	}
+
+	return values[0], nil
+}
+
+func intParam(q url.Values, name string) (int, error) {
+	s, err := param(q, name)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &paramError{name, fmt.Sprintf("%q is not an integer", s)}
+	}
+
+	return v, nil
+}
+
+// floatParam parses a finite float64; NaN and infinities are rejected.
+func floatParam(q url.Values, name string) (float64, error) {
+	s, err := param(q, name)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, &paramError{name, fmt.Sprintf("%q is not a finite number", s)}
+	}
+
+	return v, nil
+}
+
+// rangeParam parses an integer parameter and checks that it lies in [lo, hi].
+func rangeParam(q url.Values, name string, lo, hi int) (int, error) {
+	v, err := intParam(q, name)
+	if err != nil {
+		return 0, err
+	}
+	if v < lo || v > hi {
+		return 0, &paramError{name, fmt.Sprintf("must be between %d and %d, got %d", lo, hi, v)}
+	}
+
+	return v, nil
+}
+
+// vertexParam parses a vertex written as "(X,Y)".
+func vertexParam(q url.Values, name string) (moretypes.Vertex, error) {
+	s, err := param(q, name)
+	if err != nil {
+		return moretypes.Vertex{}, err
+	}
+
+	v, err := moretypes.ParseVertex(s)
+	if err != nil {
+		var verr *moretypes.VertexError
+		if errors.As(err, &verr) {
+			return moretypes.Vertex{}, &paramError{name, fmt.Sprintf("%q: at offset %d: %s", s, verr.Offset, verr.Msg)}
+		}
+		return moretypes.Vertex{}, &paramError{name, err.Error()}
+	}
+
+	return v, nil
+}
+
+// twoInts parses the x and y parameters used by the arithmetic endpoints.
+func twoInts(q url.Values) (x, y int, err error) {
+	if x, err = intParam(q, "x"); err != nil {
+		return
+	}
+	y, err = intParam(q, "y")
+
+	return
+}
+
+func basicsAdd(q url.Values) (report.Record, error) {
+	x, y, err := twoInts(q)
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	return report.Record{Name: "add", Inputs: map[string]any{"x": x, "y": y}, Result: basics.Add(x, y)}, nil
+}
+
+func basicsSubtract(q url.Values) (report.Record, error) {
+	x, y, err := twoInts(q)
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	return report.Record{Name: "subtract", Inputs: map[string]any{"x": x, "y": y}, Result: basics.Subtract(x, y)}, nil
+}
+
+func basicsSwap(q url.Values) (report.Record, error) {
+	x, err := param(q, "x")
+	if err != nil {
+		return report.Record{}, err
+	}
+	y, err := param(q, "y")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	a, b := basics.Swap(x, y)
+	return report.Record{
+		Name:   "swap",
+		Inputs: map[string]any{"x": x, "y": y},
+		Result: []any{a, b},
+		Type:   "(string, string)",
+	}, nil
+}
+
+func basicsSplit(q url.Values) (report.Record, error) {
+	sum, err := intParam(q, "sum")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	x, y := basics.Split(sum)
+	return report.Record{Name: "split", Inputs: map[string]any{"sum": sum}, Result: []any{x, y}, Type: "(int, int)"}, nil
+}
+
+func basicsNeedInt(q url.Values) (report.Record, error) {
+	x, err := intParam(q, "x")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	// x*10 + 1 is computed exactly, so a result too large for an int is reported rather than wrapped.
+	v, err := basics.ToInt(basics.NeedIntExact(big.NewInt(int64(x))))
+	if err != nil {
+		return report.Record{}, &paramError{"x", strings.TrimPrefix(err.Error(), "basics: ")}
+	}
+
+	return report.Record{Name: "needint", Inputs: map[string]any{"x": x}, Result: v}, nil
+}
+
+func basicsNeedFloat(q url.Values) (report.Record, error) {
+	x, err := floatParam(q, "x")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	return report.Record{Name: "needfloat", Inputs: map[string]any{"x": x}, Result: basics.NeedFloat(x)}, nil
+}
+
+func basicsEval(q url.Values) (report.Record, error) {
+	expr, err := param(q, "expr")
+	if err != nil {
+		return report.Record{}, err
+	}
+	if len(expr) > MaxExprLen {
+		return report.Record{}, &paramError{"expr", fmt.Sprintf("longer than %d bytes", MaxExprLen)}
+	}
+
+	var to basics.Type
+	if q.Has("to") {
+		s, err := param(q, "to")
+		if err != nil {
+			return report.Record{}, err
+		}
+		if err := to.Set(s); err != nil {
+			return report.Record{}, &paramError{"to", err.Error()}
+		}
+	}
+
+	x, err := basics.Eval(expr)
+	if err != nil {
+		var eerr *basics.ExprError
+		if errors.As(err, &eerr) {
+			return report.Record{}, &paramError{"expr", fmt.Sprintf("%q: at offset %d: %s", expr, eerr.Offset, eerr.Msg)}
+		}
+		return report.Record{}, &paramError{"expr", err.Error()}
+	}
+	v, err := basics.Convert(x, to)
+	if err != nil {
+		return report.Record{}, &paramError{"expr", strings.TrimPrefix(err.Error(), "basics: ")}
+	}
+
+	return report.Record{Name: "eval", Inputs: map[string]any{"expr": expr, "to": to.String()}, Result: v}, nil
+}
+
+func flowSqrt(q url.Values) (report.Record, error) {
+	x, err := floatParam(q, "x")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	return report.Record{Name: "sqrt", Inputs: map[string]any{"x": x}, Result: flowcontrol.Sqrt(x)}, nil
+}
+
+func flowNmsqrt(q url.Values) (report.Record, error) {
+	x, err := floatParam(q, "x")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	r, err := flowcontrol.NewtonSqrt(x)
+	if err != nil {
+		return report.Record{}, &paramError{"x", strings.TrimPrefix(err.Error(), "flowcontrol: ")}
+	}
+
+	return report.Record{Name: "nmsqrt", Inputs: map[string]any{"x": x}, Result: r.Root}, nil
+}
+
+func flowRoot(q url.Values) (report.Record, error) {
+	s, err := param(q, "z")
+	if err != nil {
+		return report.Record{}, err
+	}
+	z, err := strconv.ParseComplex(s, 128)
+	if err != nil {
+		return report.Record{}, &paramError{"z", fmt.Sprintf("%q is not a complex number", s)}
+	}
+	n, err := rangeParam(q, "n", 1, MaxRootDegree)
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	mode := flowcontrol.Principal
+	if q.Has("roots") {
+		s, err := param(q, "roots")
+		if err != nil {
+			return report.Record{}, err
+		}
+		if err := mode.Set(s); err != nil {
+			return report.Record{}, &paramError{"roots", err.Error()}
+		}
+	}
+
+	roots, err := flowcontrol.Roots(z, n, mode)
+	if err != nil {
+		return report.Record{}, &paramError{"n", err.Error()}
+	}
+	rec := report.Record{Name: "root", Inputs: map[string]any{"z": z, "n": n, "roots": string(mode)}, Result: roots}
+	if mode == flowcontrol.Principal {
+		rec.Result = roots[0]
+	}
+
+	return rec, nil
+}
+
+func flowPow(q url.Values) (report.Record, error) {
+	var xnl [3]float64
+	for i, name := range []string{"x", "n", "lim"} {
+		v, err := floatParam(q, name)
+		if err != nil {
+			return report.Record{}, err
+		}
+		xnl[i] = v
+	}
+
+	inputs := map[string]any{"x": xnl[0], "n": xnl[1], "lim": xnl[2]}
+	b := flowcontrol.UpTo(xnl[2])
+	if q.Has("min") {
+		v, err := floatParam(q, "min")
+		if err != nil {
+			return report.Record{}, err
+		}
+		b.Lower, inputs["min"] = v, v
+	}
+
+	r, err := flowcontrol.ClampPow(xnl[0], xnl[1], b)
+	if err != nil {
+		return report.Record{}, &paramError{"min", "must be a number no greater than lim"}
+	}
+
+	return report.Record{Name: "pow", Inputs: inputs, Result: r.Value}, nil
+}
+
+// clockParams reads the optional "now" (RFC 3339) and "tz" (IANA name, default UTC) parameters.
+func clockParams(q url.Values, c flowcontrol.Clock) (flowcontrol.Clock, *time.Location, error) {
+	loc := time.UTC
+	if q.Has("tz") {
+		name, err := param(q, "tz")
+		if err != nil {
+			return nil, nil, err
+		}
+		if loc, err = time.LoadLocation(name); err != nil {
+			return nil, nil, &paramError{"tz", fmt.Sprintf("unknown time zone %q", name)}
+		}
+	}
+
+	if q.Has("now") {
+		s, err := param(q, "now")
+		if err != nil {
+			return nil, nil, err
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, nil, &paramError{"now", fmt.Sprintf("%q is not an RFC 3339 time", s)}
+		}
+		c = flowcontrol.FixedClock(t)
+	}
+
+	return c, loc, nil
+}
+
+func flowSaturday(clock flowcontrol.Clock) endpoint {
+	return func(q url.Values) (report.Record, error) {
+		c, loc, err := clockParams(q, clock)
+		if err != nil {
+			return report.Record{}, err
+		}
+
+		return report.Record{
+			Name:   "saturday",
+			Inputs: map[string]any{"now": c.Now().In(loc)},
+			Result: flowcontrol.WhenIsSaturday(c, loc),
+		}, nil
+	}
+}
+
+func flowGreeting(clock flowcontrol.Clock) endpoint {
+	return func(q url.Values) (report.Record, error) {
+		c, loc, err := clockParams(q, clock)
+		if err != nil {
+			return report.Record{}, err
+		}
+
+		return report.Record{
+			Name:   "greeting",
+			Inputs: map[string]any{"now": c.Now().In(loc)},
+			Result: flowcontrol.Greeting(c, loc),
+		}, nil
+	}
+}
+
+// typesPic builds a picture with the pixel function named by the optional "func" parameter
+// (see picture.Generators), Pic's own x*x + y*y by default. The optional "mode" parameter
+// (wrap, clamp or normalize) picks how values outside 0-255 are handled.
+func typesPic(q url.Values) (report.Record, error) {
+	dx, err := rangeParam(q, "dx", 0, MaxPicSize)
+	if err != nil {
+		return report.Record{}, err
+	}
+	dy, err := rangeParam(q, "dy", 0, MaxPicSize)
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	gen := picture.DefaultGenerator
+	if q.Has("func") {
+		if gen, err = param(q, "func"); err != nil {
+			return report.Record{}, err
+		}
+	}
+	fn, err := picture.Lookup(gen)
+	if err != nil {
+		return report.Record{}, &paramError{"func", fmt.Sprintf("unknown generator %q", gen)}
+	}
+
+	mode := moretypes.Wrap
+	if q.Has("mode") {
+		s, err := param(q, "mode")
+		if err != nil {
+			return report.Record{}, err
+		}
+		if err := mode.Set(s); err != nil {
+			return report.Record{}, &paramError{"mode", err.Error()}
+		}
+	}
+
+	return report.Record{
+		Name:   "pic",
+		Inputs: map[string]any{"dx": dx, "dy": dy, "func": gen, "mode": mode},
+		Result: moretypes.PicMode(dx, dy, fn, mode),
+	}, nil
+}
+
+func typesFibonacci(q url.Values) (report.Record, error) {
+	n, err := rangeParam(q, "n", 0, MaxFibonacci)
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	fib := moretypes.Fibonacci()
+	seq := make([]int, n)
+	for i := range seq {
+		seq[i] = fib()
+	}
+
+	return report.Record{Name: "fibonacci", Inputs: map[string]any{"n": n}, Result: seq}, nil
+}
+
+// typesAdder feeds every "x" parameter, in order, to one moretypes.Adder and returns the running sums.
+func typesAdder(q url.Values) (report.Record, error) {
+	values := q["x"]
+	if len(values) == 0 {
+		return report.Record{}, &paramError{"x", "required"}
+	}
+
+	xs := make([]int, len(values))
+	sums := make([]int, len(values))
+	sum := moretypes.Adder()
+	for i, s := range values {
+		x, err := strconv.Atoi(s)
+		if err != nil {
+			return report.Record{}, &paramError{"x", fmt.Sprintf("%q is not an integer", s)}
+		}
+		xs[i], sums[i] = x, sum(x)
+	}
+
+	return report.Record{Name: "adder", Inputs: map[string]any{"x": xs}, Result: sums}, nil
+}
+
+func typesCompute(q url.Values) (report.Record, error) {
+	name, err := param(q, "fn")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	fn, err := moretypes.ComputeFunc(name)
+	if err != nil {
+		return report.Record{}, &paramError{"fn", err.Error()}
+	}
+
+	return report.Record{Name: "compute", Inputs: map[string]any{"fn": name}, Result: moretypes.Compute(fn)}, nil
+}
+
+func typesVector(q url.Values) (report.Record, error) {
+	v, err := vertexParam(q, "v")
+	if err != nil {
+		return report.Record{}, err
+	}
+	w, err := vertexParam(q, "w")
+	if err != nil {
+		return report.Record{}, err
+	}
+
+	return report.Record{
+		Name:   "vector",
+		Inputs: map[string]any{"v": v, "w": w},
+		Result: map[string]any{
+			"sum":        v.Add(w),
+			"difference": v.Sub(w),
+			"dot":        v.Dot(w),
+			"cross":      v.Cross(w),
+			"length":     v.Length(),
+			"equal":      v.Equal(w),
+		},
+	}, nil
+}
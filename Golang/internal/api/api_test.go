@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charlesrclark1243/SWE-Angular-Golang-Practice/Golang/02-FlowControl/flowcontrol"
+)
+
+func TestEndpoints(t *testing.T) {
+	// a Wednesday evening.
+	now := flowcontrol.FixedClock(time.Date(2026, 1, 14, 19, 0, 0, 0, time.UTC))
+	h := NewHandler(Options{Clock: now})
+
+	tests := []struct {
+		target string
+		status int
+		body   string
+	}{
+		{"/basics/add?x=42&y=13", 200, `{"name":"add","inputs":{"x":42,"y":13},"result":55,"type":"int"}`},
+		{"/basics/subtract?x=42&y=13", 200, `{"name":"subtract","inputs":{"x":42,"y":13},"result":29,"type":"int"}`},
+		{"/basics/swap?x=hello&y=world", 200, `{"name":"swap","inputs":{"x":"hello","y":"world"},"result":["world","hello"],"type":"(string, string)"}`},
+		{"/basics/split?sum=17", 200, `{"name":"split","inputs":{"sum":17},"result":[7,10],"type":"(int, int)"}`},
+		{"/basics/needint?x=2", 200, `{"name":"needint","inputs":{"x":2},"result":21,"type":"int"}`},
+		{"/basics/eval?expr=big%20%3E%3E%2099&to=int", 200, `{"name":"eval","inputs":{"expr":"big \u003e\u003e 99","to":"int"},"result":2,"type":"int"}`},
+		{"/basics/eval?expr=1%3C%3C100", 200, `{"name":"eval","inputs":{"expr":"1\u003c\u003c100","to":"exact"},"result":"1267650600228229401496703205376","type":"string"}`},
+		{"/basics/needfloat?x=2", 200, `{"name":"needfloat","inputs":{"x":2},"result":0.2,"type":"float64"}`},
+		{"/flow/sqrt?x=-4", 200, `{"name":"sqrt","inputs":{"x":-4},"result":"2i","type":"string"}`},
+		{"/flow/nmsqrt?x=2", 200, `{"name":"nmsqrt","inputs":{"x":2},"result":1.4142135623730951,"type":"float64"}`},
+		{"/flow/nmsqrt?x=1e300", 200, `{"name":"nmsqrt","inputs":{"x":1e+300},"result":1e+150,"type":"float64"}`},
+		{"/flow/root?z=-4&n=2", 200, `{"name":"root","inputs":{"n":2,"roots":"principal","z":{"real":-4,"imag":0}},"result":{"real":0,"imag":2},"type":"complex128"}`},
+		{"/flow/root?z=16&n=4&roots=all", 200, `{"name":"root","inputs":{"n":4,"roots":"all","z":{"real":16,"imag":0}},"result":[{"real":2,"imag":0},{"real":0,"imag":2},{"real":-2,"imag":0},{"real":0,"imag":-2}],"type":"[]complex128"}`},
+		{"/flow/pow?x=3&n=2&lim=10", 200, `{"name":"pow","inputs":{"lim":10,"n":2,"x":3},"result":9,"type":"float64"}`},
+		{"/flow/pow?x=3&n=3&lim=20", 200, `{"name":"pow","inputs":{"lim":20,"n":3,"x":3},"result":20,"type":"float64"}`},
+		{"/flow/pow?x=0.5&n=2&lim=1&min=0.5", 200, `{"name":"pow","inputs":{"lim":1,"min":0.5,"n":2,"x":0.5},"result":0.5,"type":"float64"}`},
+		{"/flow/saturday", 200, `{"name":"saturday","inputs":{"now":"2026-01-14T19:00:00Z"},"result":"too far away :(","type":"string"}`},
+		{"/flow/saturday?now=2026-01-16T12:00:00Z", 200, `{"name":"saturday","inputs":{"now":"2026-01-16T12:00:00Z"},"result":"tomorrow!","type":"string"}`},
+		{"/flow/greeting", 200, `{"name":"greeting","inputs":{"now":"2026-01-14T19:00:00Z"},"result":"Good evening.","type":"string"}`},
+		{"/types/pic?dx=3&dy=2", 200, `{"name":"pic","inputs":{"dx":3,"dy":2,"func":"squares","mode":"wrap"},"result":[[0,1,4],[1,2,5]],"type":"[][]uint8"}`},
+		{"/types/pic?dx=3&dy=2&func=xor", 200, `{"name":"pic","inputs":{"dx":3,"dy":2,"func":"xor","mode":"wrap"},"result":[[0,1,2],[1,0,3]],"type":"[][]uint8"}`},
+		{"/types/pic?dx=3&dy=1&func=product&mode=normalize", 200, `{"name":"pic","inputs":{"dx":3,"dy":1,"func":"product","mode":"normalize"},"result":[[0,0,0]],"type":"[][]uint8"}`},
+		{"/types/pic?dx=20&dy=1&mode=clamp", 200, `{"name":"pic","inputs":{"dx":20,"dy":1,"func":"squares","mode":"clamp"},"result":[[0,1,4,9,16,25,36,49,64,81,100,121,144,169,196,225,255,255,255,255]],"type":"[][]uint8"}`},
+		{"/types/fibonacci?n=5", 200, `{"name":"fibonacci","inputs":{"n":5},"result":[1,1,2,3,5],"type":"[]int"}`},
+		{"/types/adder?x=1&x=2&x=3", 200, `{"name":"adder","inputs":{"x":[1,2,3]},"result":[1,3,6],"type":"[]int"}`},
+		{"/types/compute?fn=hypot", 200, `{"name":"compute","inputs":{"fn":"hypot"},"result":5,"type":"float64"}`},
+		{"/types/vector?v=(3,4)&w=(-1,%202)", 200, `{"name":"vector","inputs":{"v":{"X":3,"Y":4},"w":{"X":-1,"Y":2}},"result":{"cross":10,"difference":{"X":4,"Y":2},"dot":5,"equal":false,"length":5,"sum":{"X":2,"Y":6}},"type":"map[string]interface {}"}`},
+
+		{"/basics/add?x=1", 400, `{"error":"parameter \"y\": required"}`},
+		{"/basics/add?x=1&y=two", 400, `{"error":"parameter \"y\": \"two\" is not an integer"}`},
+		{"/basics/add?x=1&x=2&y=3", 400, `{"error":"parameter \"x\": given more than once"}`},
+		{"/flow/sqrt?x=NaN", 400, `{"error":"parameter \"x\": \"NaN\" is not a finite number"}`},
+		{"/flow/nmsqrt?x=-4", 400, `{"error":"parameter \"x\": square root of a negative number"}`},
+		{"/flow/root?z=1%2B2i&n=0", 400, `{"error":"parameter \"n\": must be between 1 and 1000, got 0"}`},
+		{"/flow/root?z=1+2i&n=2", 400, `{"error":"parameter \"z\": \"1 2i\" is not a complex number"}`},
+		{"/flow/pow?x=3&n=3", 400, `{"error":"parameter \"lim\": required"}`},
+		{"/flow/pow?x=3&n=3&lim=1&min=2", 400, `{"error":"parameter \"min\": must be a number no greater than lim"}`},
+		{"/flow/greeting?tz=Nowhere/Special", 400, `{"error":"parameter \"tz\": unknown time zone \"Nowhere/Special\""}`},
+		{"/flow/greeting?now=yesterday", 400, `{"error":"parameter \"now\": \"yesterday\" is not an RFC 3339 time"}`},
+		{"/types/pic?dx=-1&dy=2", 400, `{"error":"parameter \"dx\": must be between 0 and 1024, got -1"}`},
+		{"/types/pic?dx=1&dy=1&func=spiral", 400, `{"error":"parameter \"func\": unknown generator \"spiral\""}`},
+		{"/types/pic?dx=1&dy=1&mode=saturate", 400, `{"error":"parameter \"mode\": unknown mode \"saturate\" (want wrap, clamp or normalize)"}`},
+		{"/types/fibonacci?n=93", 400, `{"error":"parameter \"n\": must be between 0 and 92, got 93"}`},
+		{"/types/adder", 400, `{"error":"parameter \"x\": required"}`},
+		{"/types/vector?v=(3,4)&w=(1x2)", 400, `{"error":"parameter \"w\": \"(1x2)\": at offset 2: expected ',' after X, found 'x'"}`},
+		{"/types/compute?fn=max", 400, `{"error":"parameter \"fn\": unknown function \"max\" (want hypot or pow)"}`},
+
+		{"/basics/needint?x=9223372036854775807", 400, `{"error":"parameter \"x\": constant 92233720368547758071 overflows int"}`},
+		{"/basics/needint?x=-922337203685477581", 400, `{"error":"parameter \"x\": constant -9223372036854775809 overflows int"}`},
+		{"/basics/eval?expr=big&to=int", 400, `{"error":"parameter \"expr\": constant 1267650600228229401496703205376 overflows int"}`},
+		{"/basics/eval?expr=1%2B", 400, `{"error":"parameter \"expr\": \"1+\": at offset 2: expected operand, found 'EOF'"}`},
+		{"/basics/eval?expr=1e-1000000", 400, `{"error":"parameter \"expr\": \"1e-1000000\": at offset 0: exponent of 1e-1000000 too large (max 10000)"}`},
+		{"/basics/eval?expr=" + strings.Repeat("1%2B", 512) + "1", 400, `{"error":"parameter \"expr\": longer than 1024 bytes"}`},
+		{"/basics/eval?expr=1" + strings.Repeat("%3C%3C9999", 20), 400, `{"error":"parameter \"expr\": \"1` + strings.Repeat(`\u003c\u003c9999`, 20) + `\": at offset 0: value too large (more than 100000 bits)"}`},
+		{"/basics/eval?expr=1&to=int8", 400, `{"error":"parameter \"to\": unknown type \"int8\" (want exact, int or float64)"}`},
+		{"/basics/multiply?x=1&y=2", 404, ""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("GET %s: status %d, want %d", tt.target, rec.Code, tt.status)
+		}
+		if tt.body == "" {
+			continue
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("GET %s: Content-Type %q, want application/json", tt.target, ct)
+		}
+		if got := strings.TrimSpace(rec.Body.String()); got != tt.body {
+			t.Errorf("GET %s:\n\tgot  %s\n\twant %s", tt.target, got, tt.body)
+		}
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(Options{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/basics/add?x=1&y=2", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /basics/add: status %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestAllowOrigin(t *testing.T) {
+	h := NewHandler(Options{AllowOrigin: "http://localhost:4200"})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/basics/add?x=1&y=2", nil))
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:4200" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, "http://localhost:4200")
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["result"] != 3.0 {
+		t.Errorf("result = %v, want 3", body["result"])
+	}
+}
+
+// brokenWriter is a ResponseWriter whose client has gone away.
+type brokenWriter struct{ *httptest.ResponseRecorder }
+
+func (brokenWriter) Write([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestWriteErrorLogged(t *testing.T) {
+	var logged strings.Builder
+	h := NewHandler(Options{ErrorLog: log.New(&logged, "", 0)})
+
+	h.ServeHTTP(brokenWriter{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/basics/add?x=1&y=2", nil))
+
+	if got, want := logged.String(), "/basics/add: writing response: connection reset\n"; got != want {
+		t.Errorf("logged %q, want %q", got, want)
+	}
+}
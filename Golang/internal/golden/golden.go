@@ -0,0 +1,174 @@
+// Package golden checks that each lesson's demo program still prints the output shown in its README.
+//
+// Every lesson README has a fenced block introduced by "The output should look as follows". Expected extracts
+// that block, Compare diffs it against real output line by line, and Run ties the two together by running the
+// lesson's main package with `go run`.
+//
+// Some lines can't be compared literally (the OS name, the weekday, the time of day), so a Rule can swap the
+// literal README line for a regular expression.
+package golden
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// marker is the sentence every lesson README uses to introduce its expected output.
+const marker = "output should look as follows"
+
+// annotation matches a trailing "// ..." note that a README adds to explain a line (it isn't printed).
+var annotation = regexp.MustCompile(`\s+//.*$`)
+
+// Rule marks an expected line as nondeterministic: when the README line equals Line, the actual
+// output line only has to match Pattern.
+type Rule struct {
+	Line    string
+	Pattern *regexp.Regexp
+}
+
+// Vary returns a Rule that matches the README line `line` against the regular expression `pattern`.
+// It panics if pattern doesn't compile, like regexp.MustCompile.
+func Vary(line, pattern string) Rule {
+	return Rule{Line: line, Pattern: regexp.MustCompile(pattern)}
+}
+
+// Expected reads the README at path and returns the lines of its expected-output block, with trailing
+// whitespace and "// ..." annotations removed. A missing, unclosed or empty block is an error, so a broken
+// README can't pass by expecting nothing.
+func Expected(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		lines   []string
+		seen    bool // marker sentence found
+		inBlock bool // inside the fenced block that follows it
+	)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case !seen:
+			seen = strings.Contains(line, marker)
+		case !inBlock:
+			inBlock = strings.HasPrefix(line, "```")
+		case strings.HasPrefix(line, "```"):
+			if lines = trim(lines); len(lines) == 0 {
+				return nil, fmt.Errorf("%s: expected-output block is empty", path)
+			}
+			return lines, nil
+		default:
+			lines = append(lines, annotation.ReplaceAllString(line, ""))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !seen {
+		return nil, fmt.Errorf("%s: no %q block", path, marker)
+	}
+	if !inBlock {
+		return nil, fmt.Errorf("%s: no fenced block after %q", path, marker)
+	}
+
+	return nil, fmt.Errorf("%s: expected-output block is not closed", path)
+}
+
+// Compare diffs the actual output against the expected lines, applying rules to nondeterministic lines.
+// It returns nil if they match, or an error listing every line that differs.
+func Compare(want []string, got string, rules ...Rule) error {
+	actual := trim(strings.Split(got, "\n"))
+
+	var diffs []string
+	for i := 0; i < len(want) || i < len(actual); i++ {
+		var w, a string
+		if i < len(want) {
+			w = want[i]
+		}
+		if i < len(actual) {
+			a = actual[i]
+		}
+
+		switch {
+		case i >= len(want):
+			diffs = append(diffs, fmt.Sprintf("line %d: unexpected %q", i+1, a))
+		case i >= len(actual):
+			diffs = append(diffs, fmt.Sprintf("line %d: missing %q", i+1, w))
+		case !matches(w, a, rules):
+			diffs = append(diffs, fmt.Sprintf("line %d:\n\twant %q\n\tgot  %q", i+1, w, a))
+		}
+	}
+
+	if len(diffs) > 0 {
+		return errors.New(strings.Join(diffs, "\n"))
+	}
+
+	return nil
+}
+
+// Run builds and runs the main package in dir with args, then compares its standard output against the
+// README.md in the same directory. It skips the test if the go tool isn't available.
+func Run(t *testing.T, dir string, args []string, rules ...Rule) {
+	t.Helper()
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found:", err)
+	}
+
+	want, err := Expected(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(goTool, append([]string{"run", "."}, args...)...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("go run %s: %v\n%s", dir, err, stderr.String())
+	}
+
+	if err := Compare(want, stdout.String(), rules...); err != nil {
+		t.Errorf("output differs from README.md:\n%v", err)
+	}
+}
+
+// matches reports whether the actual line a satisfies the expected line w.
+func matches(w, a string, rules []Rule) bool {
+	for _, r := range rules {
+		if r.Line == w {
+			return r.Pattern.MatchString(a)
+		}
+	}
+
+	return w == a
+}
+
+// trim removes trailing whitespace from every line and drops trailing blank lines.
+func trim(lines []string) []string {
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
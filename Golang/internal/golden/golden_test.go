@@ -0,0 +1,137 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeREADME writes content to a README.md in a new temporary directory and returns its path.
+func writeREADME(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "README.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestExpected(t *testing.T) {
+	path := writeREADME(t, "# Lesson\n"+
+		"```go\nfmt.Println(\"not this block\")\n```\n"+
+		"The output should look as follows:\n\n"+
+		"```\n"+
+		"Sum from 0 to 9 is: 45   \n"+
+		"2i // sqrt(-4)\n"+
+		"\n"+
+		"https://go.dev\n"+
+		"\n\n"+
+		"```\n"+
+		"```\nnor this one\n```\n")
+
+	got, err := Expected(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the fence before the marker and the one after the block are ignored, annotations and trailing
+	// whitespace are removed, and blank lines are kept except at the end. "//" in a URL has no space before
+	// it, so it isn't an annotation.
+	want := []string{"Sum from 0 to 9 is: 45", "2i", "", "https://go.dev"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected = %q, want %q", got, want)
+	}
+}
+
+func TestExpectedErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		msg     string
+	}{
+		{"no marker", "# Lesson\n```\n45\n```\n", "no \"output should look as follows\" block"},
+		{"no fence", "The output should look as follows:\n\n45\n", "no fenced block after"},
+		{"unclosed fence", "The output should look as follows:\n```\n45\n", "not closed"},
+		{"empty block", "The output should look as follows:\n```\n\n```\n", "empty"},
+	}
+
+	for _, tt := range tests {
+		got, err := Expected(writeREADME(t, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.msg) {
+			t.Errorf("%s: Expected = %q, %v; want an error containing %q", tt.name, got, err, tt.msg)
+		}
+	}
+
+	if _, err := Expected(filepath.Join(t.TempDir(), "README.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected on a missing file: %v", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	want := []string{"9", "27 >= 20", "20"}
+
+	if err := Compare(want, "9\n27 >= 20\n20\n\n"); err != nil {
+		t.Errorf("identical output: %v", err)
+	}
+	if err := Compare(want, "9  \n27 >= 20\t\n20"); err != nil {
+		t.Errorf("trailing whitespace: %v", err)
+	}
+
+	tests := []struct {
+		got  string
+		diff []string
+	}{
+		{"9\n27 >= 20\n21\n", []string{"line 3:", `want "20"`, `got  "21"`}},
+		{"9\n27 >= 20\n", []string{`line 3: missing "20"`}},
+		{"9\n27 >= 20\n20\nextra\n", []string{`line 4: unexpected "extra"`}},
+		{"8\n27 >= 20\n21\n", []string{"line 1:", "line 3:"}},
+		{"", []string{`line 1: missing "9"`, `line 3: missing "20"`}},
+	}
+	for _, tt := range tests {
+		err := Compare(want, tt.got)
+		if err == nil {
+			t.Errorf("Compare(%q) succeeded", tt.got)
+			continue
+		}
+		for _, d := range tt.diff {
+			if !strings.Contains(err.Error(), d) {
+				t.Errorf("Compare(%q) = %q, want it to mention %q", tt.got, err, d)
+			}
+		}
+	}
+}
+
+func TestVary(t *testing.T) {
+	want := []string{"Go runs on Linux.", "Saturday is in two days.", "Good evening."}
+	rules := []Rule{
+		Vary("Go runs on Linux.", `^Go runs on .+\.$`),
+		Vary("Good evening.", `^Good (morning!|afternoon\.|evening\.)$`),
+	}
+
+	if err := Compare(want, "Go runs on OS X.\nSaturday is in two days.\nGood morning!\n", rules...); err != nil {
+		t.Errorf("varying lines: %v", err)
+	}
+
+	// a rule replaces the literal comparison, so its line must match the pattern...
+	if err := Compare(want, "Go runs on Linux\nSaturday is in two days.\nGood evening.\n", rules...); err == nil || !strings.Contains(err.Error(), "line 1:") {
+		t.Errorf("OS line without its full stop: %v", err)
+	}
+	// ...and only applies to the README line it names.
+	if err := Compare(want, "Go runs on Linux.\nSaturday is today!!!\nGood evening.\n", rules...); err == nil || !strings.Contains(err.Error(), "line 2:") {
+		t.Errorf("line without a rule: %v", err)
+	}
+	// without rules, varying lines are compared literally.
+	if err := Compare(want, "Go runs on OS X.\nSaturday is in two days.\nGood evening.\n"); err == nil {
+		t.Error("Compare without rules accepted a different OS")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Vary with an invalid pattern did not panic")
+		}
+	}()
+	Vary("x", "(")
+}
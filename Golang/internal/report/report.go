@@ -0,0 +1,169 @@
+// Package report prints the results of the lesson examples either as the lessons' usual text or as
+// structured JSON records that other programs (like the Angular front end) can parse.
+//
+// Each example hands the Printer a Record describing what it computed together with the text it would
+// normally print. In text mode only the text is written; in JSON mode only the record is, one per line.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format selects how a Printer writes examples. It implements flag.Value so it can be bound to a
+// -format flag directly.
+type Format string
+
+const (
+	Text Format = "text" // the lessons' usual output
+	JSON Format = "json" // one JSON Record per line
+)
+
+// String returns the name of the format.
+func (f *Format) String() string {
+	if f == nil || *f == "" {
+		return string(Text)
+	}
+
+	return string(*f)
+}
+
+// Set parses s as a Format.
+func (f *Format) Set(s string) error {
+	switch Format(s) {
+	case Text, JSON:
+		*f = Format(s)
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q (want %s or %s)", s, Text, JSON)
+	}
+}
+
+// Record is the structured form of one example's output.
+type Record struct {
+	Name   string         `json:"name"`             // what the example demonstrates, e.g. "swap"
+	Inputs map[string]any `json:"inputs,omitempty"` // the arguments it was given, by parameter name
+	Result any            `json:"result"`           // what it computed
+	Type   string         `json:"type"`             // Go type of Result; filled in from Result if empty
+}
+
+// Printer writes examples to W in the selected Format. The zero Format is Text.
+type Printer struct {
+	W      io.Writer
+	Format Format
+}
+
+// NewPrinter returns a Printer that writes to w in format f.
+func NewPrinter(w io.Writer, f Format) *Printer {
+	return &Printer{W: w, Format: f}
+}
+
+// Println writes r in JSON mode, or the operands formatted as by fmt.Println in text mode.
+func (p *Printer) Println(r Record, a ...any) {
+	p.emit(r, func() { fmt.Fprintln(p.W, a...) })
+}
+
+// Printf writes r in JSON mode, or the operands formatted as by fmt.Printf in text mode.
+func (p *Printer) Printf(r Record, format string, a ...any) {
+	p.emit(r, func() { fmt.Fprintf(p.W, format, a...) })
+}
+
+// Textln writes the operands as by fmt.Println, but only in text mode. It is for headings,
+// separators and other output that isn't the result of an example.
+func (p *Printer) Textln(a ...any) {
+	if p.Format != JSON {
+		fmt.Fprintln(p.W, a...)
+	}
+}
+
+// Textf writes the operands as by fmt.Printf, but only in text mode.
+func (p *Printer) Textf(format string, a ...any) {
+	if p.Format != JSON {
+		fmt.Fprintf(p.W, format, a...)
+	}
+}
+
+func (p *Printer) emit(r Record, text func()) {
+	if p.Format != JSON {
+		text()
+		return
+	}
+
+	b, err := json.Marshal(Normalize(r))
+	if err != nil {
+		// should be unreachable: jsonValue falls back to a string for anything json can't encode.
+		b, _ = json.Marshal(Record{Name: r.Name, Result: err.Error(), Type: "error"})
+	}
+	fmt.Fprintf(p.W, "%s\n", b)
+}
+
+// Normalize returns a copy of r that encoding/json can always encode: Type is filled in from Result if it
+// is empty, and Result and Inputs are converted as described on Complex and jsonValue.
+func Normalize(r Record) Record {
+	if r.Type == "" {
+		r.Type = fmt.Sprintf("%T", r.Result)
+	}
+
+	r.Result = jsonValue(r.Result)
+	if r.Inputs != nil {
+		r.Inputs = jsonValue(r.Inputs).(map[string]any)
+	}
+
+	return r
+}
+
+// Complex is the JSON form of a complex number.
+type Complex struct {
+	Real float64 `json:"real"`
+	Imag float64 `json:"imag"`
+}
+
+// jsonValue converts v to something encoding/json can encode: complex numbers become Complex, byte slices
+// become arrays of numbers rather than base64 strings, []any and map[string]any are converted element by
+// element, and any other value json rejects (NaN, infinities, functions, ...) becomes its fmt.Sprint string.
+func jsonValue(v any) any {
+	switch c := v.(type) {
+	case complex128:
+		return jsonValue(Complex{Real: real(c), Imag: imag(c)})
+	case complex64:
+		return jsonValue(complex128(c))
+	case []complex128:
+		values := make([]any, len(c))
+		for i, e := range c {
+			values[i] = jsonValue(e)
+		}
+		return values
+	case []uint8:
+		values := make([]int, len(c))
+		for i, e := range c {
+			values[i] = int(e)
+		}
+		return values
+	case [][]uint8:
+		values := make([]any, len(c))
+		for i, e := range c {
+			values[i] = jsonValue(e)
+		}
+		return values
+	case []any:
+		values := make([]any, len(c))
+		for i, e := range c {
+			values[i] = jsonValue(e)
+		}
+		return values
+	case map[string]any:
+		values := make(map[string]any, len(c))
+		for k, e := range c {
+			values[k] = jsonValue(e)
+		}
+		return values
+	}
+
+	if _, err := json.Marshal(v); err != nil {
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+
+	return v
+}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPrinterText(t *testing.T) {
+	var b strings.Builder
+	p := NewPrinter(&b, Text)
+
+	p.Println(Record{Name: "add", Result: 55}, 55)
+	p.Printf(Record{Name: "split", Result: []any{7, 10}}, "%d %d\n", 7, 10)
+	p.Textln("heading")
+	p.Textf("%s", "no newline")
+
+	if got, want := b.String(), "55\n7 10\nheading\nno newline"; got != want {
+		t.Errorf("text output = %q, want %q", got, want)
+	}
+}
+
+func TestPrinterJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		record Record
+		want   string
+	}{
+		{
+			"type from result",
+			Record{Name: "add", Inputs: map[string]any{"x": 42, "y": 13}, Result: 55},
+			`{"name":"add","inputs":{"x":42,"y":13},"result":55,"type":"int"}`,
+		},
+		{
+			"explicit type",
+			Record{Name: "swap", Result: []any{"world", "hello"}, Type: "(string, string)"},
+			`{"name":"swap","result":["world","hello"],"type":"(string, string)"}`,
+		},
+		{
+			"complex",
+			Record{Name: "z", Result: complex(1, 2)},
+			`{"name":"z","result":{"real":1,"imag":2},"type":"complex128"}`,
+		},
+		{
+			"nested complex",
+			Record{Name: "g", Result: map[string]any{"g": 0.5i}},
+			`{"name":"g","result":{"g":{"real":0,"imag":0.5}},"type":"map[string]interface {}"}`,
+		},
+		{
+			"complex slice",
+			Record{Name: "roots", Result: []complex128{2, -1 + 1.5i}},
+			`{"name":"roots","result":[{"real":2,"imag":0},{"real":-1,"imag":1.5}],"type":"[]complex128"}`,
+		},
+		{
+			"bytes as numbers",
+			Record{Name: "pic", Result: [][]uint8{{0, 1}, {1, 2}}},
+			`{"name":"pic","result":[[0,1],[1,2]],"type":"[][]uint8"}`,
+		},
+		{
+			"unencodable",
+			Record{Name: "inf", Result: math.Inf(1)},
+			`{"name":"inf","result":"+Inf","type":"float64"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		var b strings.Builder
+		p := NewPrinter(&b, JSON)
+
+		p.Println(tt.record, "ignored in JSON mode")
+		p.Textln("ignored too")
+
+		if got := strings.TrimSuffix(b.String(), "\n"); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+		if !json.Valid([]byte(b.String())) {
+			t.Errorf("%s: output is not valid JSON: %s", tt.name, b.String())
+		}
+	}
+}
+
+func TestFormatSet(t *testing.T) {
+	var f Format
+	if err := f.Set("json"); err != nil || f != JSON {
+		t.Errorf("Set(json) = %v, format %q", err, f)
+	}
+	if err := f.Set("xml"); err == nil {
+		t.Error("Set(xml) succeeded, want error")
+	}
+}
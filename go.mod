@@ -0,0 +1,3 @@
+module github.com/charlesrclark1243/SWE-Angular-Golang-Practice
+
+go 1.22